@@ -1,11 +1,15 @@
 package board
 
 import (
+	"BomboweStatki/layout"
+	"fmt"
 	"strconv"
 
 	gui "github.com/s25867/warships-gui/v2"
 )
 
+// Config returns states of both boards with ships of the layout on our board,
+// it fails on coordinates that are not on the board
 func Config(shipCoords []string) (playerStates [10][10]gui.State, opponentStates [10][10]gui.State, shipStatus map[string]bool, err error) {
 	playerStates = [10][10]gui.State{}
 	opponentStates = [10][10]gui.State{}
@@ -20,38 +24,61 @@ func Config(shipCoords []string) (playerStates [10][10]gui.State, opponentStates
 	shipStatus = make(map[string]bool)
 
 	for _, coord := range shipCoords {
-		shipStatus[coord] = false
+		if len(coord) < 2 || coord[0] < 'A' || coord[0] > 'J' {
+			return playerStates, opponentStates, shipStatus, fmt.Errorf("invalid coordinate %q", coord)
+		}
 		col := int(coord[0] - 'A')
-		row, _ := strconv.Atoi(coord[1:])
+		row, err := strconv.Atoi(coord[1:])
+		if err != nil || row < 1 || row > 10 {
+			return playerStates, opponentStates, shipStatus, fmt.Errorf("invalid coordinate %q", coord)
+		}
+		shipStatus[coord] = false
 		playerStates[col][row-1] = gui.Ship
 	}
 
 	return playerStates, opponentStates, shipStatus, nil
 }
 
-func GuiInit(ui *gui.GUI, playerStates [10][10]gui.State, opponentStates [10][10]gui.State) (playerBoard *gui.Board, opponentBoard *gui.Board, btnArea *gui.HandleArea) {
+// Renderer is the part of the client UI that GuiInit draws with
+type Renderer interface {
+	Draw(d gui.Drawable)
+	SetStates(b *gui.Board, states [10][10]gui.State)
+	NewHandleArea(buttons map[string]gui.Spatial) *gui.HandleArea
+}
+
+// Style holds colours and labels of the game screen, the client passes ones from its theme and language
+type Style struct {
+	Board     *gui.BoardConfig
+	Exit      gui.Color
+	ExitText  gui.Color
+	ExitLabel string
+}
 
-	boardConfig := gui.NewBoardConfig()
+func GuiInit(ui Renderer, style Style, playerStates [10][10]gui.State, opponentStates [10][10]gui.State) (playerBoard *gui.Board, opponentBoard *gui.Board, btnArea *gui.HandleArea) {
 
-	playerBoard = gui.NewBoard(1, 3, boardConfig)
-	opponentBoard = gui.NewBoard(50, 3, boardConfig)
+	playerRect, opponentRect := layout.GameBoards()
+	playerBoard = gui.NewBoard(playerRect.X, playerRect.Y, style.Board)
+	opponentBoard = gui.NewBoard(opponentRect.X, opponentRect.Y, style.Board)
 
 	exitButtonConfig := gui.NewButtonConfig()
 	exitButtonConfig.Width = 0
 	exitButtonConfig.Height = 0
-	exitButtonConfig.Width = 15
-	exitButtonConfig.BgColor = gui.Red
-	exitButton := gui.NewButton(40, 25, "Exit", exitButtonConfig)
+	exitButtonConfig.Width = layout.ControlWidth
+	exitButtonConfig.FgColor = style.ExitText
+	exitButtonConfig.BgColor = style.Exit
+	// At the end of the controls row under the boards
+	exitRect := layout.Game().Exit
+	exitButton := gui.NewButton(exitRect.X, exitRect.Y, style.ExitLabel, exitButtonConfig)
 
 	btnMapping := map[string]gui.Spatial{
 		"exitButton": exitButton,
 	}
-	btnArea = gui.NewHandleArea(btnMapping)
+	btnArea = ui.NewHandleArea(btnMapping)
 
 	ui.Draw(btnArea)
 	ui.Draw(exitButton)
 
-	playerBoard.SetStates(playerStates)
+	ui.SetStates(playerBoard, playerStates)
 
 	ui.Draw(playerBoard)
 	ui.Draw(opponentBoard)
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"BomboweStatki/layout"
+	"sync"
+)
+
+// App is shared by all screens, it owns the profile, session state, API client and theme.
+// Screens draw and listen through the embedded UI.
+type App struct {
+	UI
+	API *APIClient
+	Nav *Navigator
+
+	mu          sync.RWMutex
+	theme       *Theme
+	language    string // language of messages shown on screens, see languages
+	profile     Profile
+	profileName string
+	waiting     bool        // we are in the lobby waiting for someone to challenge us
+	logs        *LogHistory // shown in the log panel, nil if there is no panel
+}
+
+func NewApp(ui UI, api *APIClient, profile Profile, profileName string, logs *LogHistory) *App {
+	app := &App{
+		UI:          ui,
+		API:         api,
+		theme:       ThemeByName(profile.Theme),
+		language:    profile.Language,
+		profile:     profile,
+		profileName: profileName,
+		logs:        logs,
+	}
+	app.Nav = newNavigator(app)
+	startLogPanel(app)
+	return app
+}
+
+// Run shows the main menu and handles navigation between screens. If a game was
+// left in progress when the client exited, resuming it is offered first.
+func (a *App) Run() {
+	if session, err := loadSession(a.profileName); err == nil && session != nil {
+		a.Nav.Run(resumeScreen(session))
+		return
+	}
+	a.Nav.Run(mainMenuScreen())
+}
+
+// Profile returns a copy of the current profile
+func (a *App) Profile() Profile {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	profile := a.profile
+	profile.Coords = append([]string(nil), a.profile.Coords...)
+	return profile
+}
+
+// GameData returns the profile as data used to start a game
+func (a *App) GameData() GameInitData {
+	return a.Profile().GameData()
+}
+
+// UpdateProfile changes the profile and saves it to disk
+func (a *App) UpdateProfile(update func(profile *Profile)) error {
+	a.mu.Lock()
+	update(&a.profile)
+	profile, name := a.profile, a.profileName
+	a.mu.Unlock()
+	return SaveProfile(name, profile)
+}
+
+// updateProfile is UpdateProfile that reports errors on the screen
+func (a *App) updateProfile(update func(profile *Profile)) {
+	if err := a.UpdateProfile(update); err != nil {
+		statusMessage(a, layout.Message(), a.T("error.saveProfile", err), a.Theme().Error)
+	}
+}
+
+// Theme returns the colour theme screens are drawn with
+func (a *App) Theme() *Theme {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.theme
+}
+
+// SetTheme switches to the named theme, screens drawn from now on use it
+func (a *App) SetTheme(name string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.theme = ThemeByName(name)
+}
+
+// Language returns the language of messages shown on screens
+func (a *App) Language() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.language
+}
+
+func (a *App) SetLanguage(language string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.language = language
+}
+
+func (a *App) WaitingForChallenger() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.waiting
+}
+
+func (a *App) SetWaitingForChallenger(waiting bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.waiting = waiting
+}
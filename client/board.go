@@ -1,11 +1,13 @@
 package client
 
 import (
+	"BomboweStatki/layout"
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"math/rand"
-	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -56,208 +58,243 @@ func generateRandomBoard() []string {
 	return board
 }
 
-func editBoard(ui *gui.GUI, opponentBoard *gui.Board, opponentStates [10][10]gui.State, newShipLayout []string, shipTypes []int, buttonArea *gui.HandleArea) {
+// editBoard runs the ship placement editor, ships are picked from the palette,
+// previewed on the board, rotated and dropped. Placed ships can be picked up again.
+func editBoard(ctx context.Context, app *App) {
+	editorUi := EditorElements(app)
+	editor := newPlacementEditor(app.Profile().Coords)
+
+	// Listen for board and button clicks at the same time
+	cells := make(chan string)
+	clicks := make(chan string)
 	go func() {
-		// Listen for exit button click
-		ctx := context.Background()
 		for {
-			if clicked := buttonArea.Listen(ctx); clicked == "exitButton" {
-				ui.Draw(gui.NewText(30, 24, "Exiting to main menu in 2 seconds...", errorText))
-				time.Sleep(2 * time.Second)
-				go MainMenu(ui)
+			char := app.ListenBoard(ctx, editorUi.Board)
+			select {
+			case cells <- char:
+			case <-ctx.Done():
 				return
 			}
 		}
 	}()
-
-	for i := 0; i < len(shipTypes); i++ {
-
-		ui.Draw(gui.NewText(1, 2, "                                  ", errorText))
-		ui.Draw(gui.NewText(1, 1, fmt.Sprintf("Placing %x/10 ship of size %d", i+1, shipTypes[i]), nil))
-		//init map
-		ships := mapShips(newShipLayout)
-		//fix ship status
-		for idx, ship := range ships {
-			if len(ship.Coords) == shipTypes[idx] { //jesli rozmiar statku nie zgadza sie z wymaganym
-				ship.IsDestroyed = "false"
-			} else {
-				ship.IsDestroyed = "true"
+	go func() {
+		for {
+			clicked := app.ListenArea(ctx, editorUi.ButtonArea)
+			select {
+			case clicks <- clicked:
+			case <-ctx.Done():
+				return
 			}
-			ships[idx] = ship
 		}
+	}()
 
-		for j := 0; j < shipTypes[i]; j++ {
+	var held placedShip
+	holding := false
+	message := ""
 
-			char := opponentBoard.Listen(context.Background())
-			// Check if char is already part of any ship in newShipLayout
-			alreadyShot := false
-			for _, coord := range newShipLayout {
-				if coord == char {
-					alreadyShot = true
-					break
-				}
-			}
-			if alreadyShot {
-				ui.Draw(gui.NewText(1, 2, "Coordinate already part of a ship!", errorText))
-				j--
+	for {
+		drawEditor(app, editorUi, editor, held, holding, message)
+		message = ""
+
+		select {
+		case <-ctx.Done():
+			return
+		case char := <-cells:
+			col, row, err := coordToIndex(char)
+			if err != nil {
 				continue
 			}
-
-			// Check if char is in the surrounding area of any completed ship
-			isInSurroundingArea := false
-			for _, ship := range ships {
-
-				if ship.IsDestroyed == "false" {
-					for _, coord := range ship.SurroundingArea {
-						if coord == char {
-							isInSurroundingArea = true
-							break
-						}
-					}
+			switch {
+			case holding && held.Col == col && held.Row == row:
+				// Second click on the previewed position drops the ship
+				if err := editor.place(held); err != nil {
+					message = app.T("editor.cantDrop", placementMessage(app, err))
+					continue
 				}
-				if isInSurroundingArea {
-					break
+				holding = false
+			case holding:
+				held.Col, held.Row = col, row
+			default:
+				if ship, ok := editor.pickUp(col, row); ok {
+					held, holding = ship, true
+					message = app.T("editor.pickedUp", ship.size())
+				} else if remaining := editor.remaining(); len(remaining) > 0 {
+					held, holding = newStraightShip(col, row, remaining[0]), true
 				}
 			}
-			if isInSurroundingArea {
-				ui.Draw(gui.NewText(1, 2, "Coordinate is in the surrounding area of a completed ship!", errorText))
-				j--
-				continue
-			}
-			// Check if char is adjacent to any destroyed ship
-			if !alreadyShot && !isInSurroundingArea {
-				ships := mapShips(newShipLayout)
 
-				for idx, ship := range ships {
-					if len(ship.Coords) == shipTypes[idx] {
-						ship.IsDestroyed = "false"
-					} else {
-						ship.IsDestroyed = "true"
-					}
-					ships[idx] = ship
+		case clicked := <-clicks:
+			switch clicked {
+			case "ship4Button", "ship3Button", "ship2Button", "ship1Button":
+				size := int(clicked[4] - '0')
+				if editor.remainingOfSize(size) == 0 {
+					message = app.T("editor.allPlaced", size)
+					continue
 				}
-				isAdjacentToDestroyedShip := false
-				destroyedShipsExist := false
-				for _, ship := range ships {
-					if ship.IsDestroyed == "true" {
-						destroyedShipsExist = true
-						isAdjacent, err := isAdjacentShip(char, ship.Coords, 1)
-						if err != nil {
-							continue
-						}
-						if isAdjacent {
-							isAdjacentToDestroyedShip = true
-							break
-						}
-					}
+				held, holding = newStraightShip(held.Col, held.Row, size), true
+			case "rotateButton":
+				if holding {
+					held = held.rotated()
 				}
-				if destroyedShipsExist && !isAdjacentToDestroyedShip {
-					ui.Draw(gui.NewText(1, 2, "Coordinate is not adjacent the ship!", errorText))
-					j--
+			case "dropButton":
+				if !holding {
+					message = app.T("editor.pickFirst")
 					continue
 				}
-
-				newShipLayout = append(newShipLayout, char)
-				// update map
-				ships = mapShips(newShipLayout)
-
-				for idx, ship := range ships {
-					if len(ship.Coords) == shipTypes[idx] {
-						ship.IsDestroyed = "false"
-						// Mark surrounding area as missed
-						for _, surrCoord := range ship.SurroundingArea {
-							row := int(surrCoord[0] - 'A')
-							col, err := strconv.Atoi(surrCoord[1:])
-							if err == nil {
-								opponentStates[row][col-1] = gui.Miss
-							}
-						}
-					} else {
-						ship.IsDestroyed = "true"
-					}
-					ships[idx] = ship
+				if err := editor.place(held); err != nil {
+					message = app.T("editor.cantDrop", placementMessage(app, err))
+					continue
 				}
-
-				// Update opponentStates with the new ship layout
-				row := int(char[0] - 'A')
-				col, err := strconv.Atoi(char[1:])
-				if err == nil {
-					opponentStates[row][col-1] = gui.Ship
+				holding = false
+			case "undoButton":
+				holding = false
+				if !editor.undoLast() {
+					message = app.T("editor.nothingToUndo")
 				}
-
-			}
-
-			// Update opponentStates with the new ship layout
-			row := int(char[0] - 'A')
-			col, err := strconv.Atoi(char[1:])
-			if err == nil {
-				opponentStates[row][col-1] = gui.Ship
+			case "redoButton":
+				holding = false
+				if !editor.redoLast() {
+					message = app.T("editor.nothingToRedo")
+				}
+			case "clearButton":
+				holding = false
+				editor.clear()
+			case "autoButton":
+				holding = false
+				if err := editor.autoComplete(); err != nil {
+					message = placementMessage(app, err)
+				}
+			case "saveButton":
+				if !editor.complete() {
+					message = app.T("editor.placeAll", len(editor.remaining()))
+					continue
+				}
+				app.updateProfile(func(profile *Profile) { profile.Coords = editor.coords() })
+				app.Draw(gui.NewText(editorUi.Status.X, editorUi.Status.Y, fitText(app.T("editor.saved"), editorUi.Status.W), app.Theme().Text))
+				time.Sleep(2 * time.Second)
+				app.Nav.Pop(ctx)
+				return
+			case "returnButton":
+				app.Nav.Pop(ctx)
+				return
 			}
+		}
+	}
+}
 
-			opponentBoard.SetStates(opponentStates)
-
+// drawEditor shows placed ships, their surrounding area and the preview of the held ship
+func drawEditor(app *App, editorUi *EditorUI, editor *placementEditor, held placedShip, holding bool, message string) {
+	states := [10][10]gui.State{}
+	for i := range states {
+		for j := range states[i] {
+			states[i][j] = gui.Empty
 		}
+	}
 
-		// Update all ships' IsDestroyed status after placing each ship
-		ships = mapShips(newShipLayout)
-		for idx, ship := range ships {
-			if len(ship.Coords) == shipTypes[i] {
-				ship.IsDestroyed = "false"
-				// Mark surrounding area as missed
-				for _, surrCoord := range ship.SurroundingArea {
-					row := int(surrCoord[0] - 'A')
-					col, err := strconv.Atoi(surrCoord[1:])
-					if err == nil {
-						opponentStates[row][col-1] = gui.Miss
-					}
-				}
-			} else {
-				ship.IsDestroyed = "true"
+	// Ships can't be placed next to each other, show that area as missed
+	for _, ship := range editor.ships {
+		for _, cell := range ship.cells() {
+			for _, coord := range getSurroundingCoords(indexToCoord(cell[0], cell[1])) {
+				col, row, _ := coordToIndex(coord)
+				states[col][row] = gui.Miss
 			}
-			ships[idx] = ship
-
 		}
-		ui.Draw(gui.NewText(1, 1, fmt.Sprintf("Placing %x/10 ship of size %d", i+2, shipTypes[i]), nil))
+	}
+	for _, ship := range editor.ships {
+		for _, cell := range ship.cells() {
+			states[cell[0]][cell[1]] = gui.Ship
+		}
 	}
 
-	if len(newShipLayout) < 20 {
-		ui.Draw(gui.NewText(1, 0, "Layout invalid, using default", errorText))
-	} else {
-		ui.Draw(gui.NewText(1, 0, "New ship layout saved", defaultText))
-		DefaultGameInitData.Coords = make([]string, len(newShipLayout))
-		copy(DefaultGameInitData.Coords, newShipLayout)
+	status := app.T("editor.status", len(editor.ships), len(fleetSizes))
+	if holding {
+		// Legal position is shown as sunk, illegal as hit
+		previewState := gui.Sunk
+		if err := editor.canPlace(held); err != nil {
+			previewState = gui.Hit
+			if message == "" {
+				message = placementMessage(app, err)
+			}
+		}
+		for _, cell := range held.cells() {
+			if cell[0] >= 0 && cell[0] <= 9 && cell[1] >= 0 && cell[1] <= 9 {
+				states[cell[0]][cell[1]] = previewState
+			}
+		}
+		status += app.T("editor.holding", held.size(), indexToCoord(held.Col, held.Row))
+	}
+	app.SetStates(editorUi.Board, states)
+
+	statusRect := editorUi.Status
+	app.Draw(gui.NewText(statusRect.X, statusRect.Y, fitText(status, statusRect.W), app.Theme().Text))
+	app.Draw(gui.NewText(statusRect.X, statusRect.Y+1, fitText(message, statusRect.W), app.Theme().Error))
+	for i, size := range []int{4, 3, 2, 1} {
+		counter := editorUi.Counters[i]
+		app.Draw(gui.NewText(counter.X, counter.Y, fitText(app.T("editor.left", editor.remainingOfSize(size)), counter.W), app.Theme().Text))
 	}
-	time.Sleep(2 * time.Second)
-	go MainMenu(ui)
 }
 
-func opponentBoardOperations(ctx context.Context, playerToken string, opponentBoard *gui.Board, opponentStates [10][10]gui.State, ui *gui.GUI, btnArea *gui.HandleArea) {
-	var totalShots int
-	var successfulShots int
-	var shotCoordinates []string
+func opponentBoardOperations(ctx context.Context, session *gameSession, opponentBoard *gui.Board, app *App, btnArea *gui.HandleArea, boards *boardSync, recorder *gameRecorder, panel *gamePanel) {
+	playerToken := session.Token
 	var fireMapMutex sync.Mutex
-	shipsShot := []string{}
-	errorTextConfig := gui.NewTextConfig()
-	errorTextConfig.FgColor = gui.Red
-	errorTextConfig.BgColor = gui.Black
+	// With auto-fire the strategy follows all our shots, so it can take over when the turn runs out
+	settings := app.Profile().Game
+	var autoFire Strategy
+	if settings.AutoFire {
+		autoFire, _ = NewStrategy(settings.Strategy)
+	}
+	// Hints follow our shots too, outside of ranked play they are shown with the hint button
+	hintStrategy, err := NewStrategy(settings.Strategy)
+	if err != nil {
+		hintStrategy, _ = NewStrategy(defaultGameSettings.Strategy)
+	}
+	hints := newHintOverlay(app, hintStrategy)
+	if !settings.Ranked {
+		hintUi := HintElements(app, settings.Heatmap)
+		hints.heatmap, hints.marker = hintUi.Heatmap, hintUi.Marker
+		go func() {
+			for ctx.Err() == nil {
+				if clicked := app.ListenArea(ctx, hintUi.ButtonArea); clicked == "hintButton" {
+					message := app.T("game.noHint")
+					if hint := hints.show(); hint != "" {
+						message = app.T("game.hint", hint)
+					}
+					slog.Debug("hint requested", "message", message)
+					gameMessage(app, message, app.Theme().HighlightText)
+				}
+			}
+		}()
+	}
 	go func() {
-		ctx := context.Background()
-
-		for {
-			if clicked := btnArea.Listen(ctx); clicked == "exitButton" {
-				ui.Draw(gui.NewText(40, 24, "Leaving game...", errorTextConfig))
-				_, err := retryOnError(ui, func() (string, error) {
-					return AbandonGame(playerToken)
+		for ctx.Err() == nil {
+			if clicked := app.ListenArea(ctx, btnArea); clicked == "exitButton" {
+				gameMessage(app, app.T("game.leaving"), app.Theme().Error)
+				_, err := retryOnError(func() (string, error) {
+					return app.API.AbandonGame(playerToken)
 				})
 				if err != nil {
-					ui.Draw(gui.NewText(25, 24, "Error leaving game: "+err.Error(), errorTextConfig))
+					gameMessage(app, app.T("error.leaveGame", err), app.Theme().Error)
 					continue
 				}
-
+				recorder.end("lose", "abandoned")
 				return
 			}
 		}
 	}()
+	// Shots from before the client was restarted rebuild what we know about the opponent
+	for _, shot := range session.Shots {
+		sunkShip, _ := boards.fired(shot.Coord, shot.Result)
+		panel.fired(shot.Result, len(sunkShip))
+		if autoFire != nil {
+			autoFire.Record(shot.Coord, shot.Result)
+		}
+		hints.record(shot.Coord, shot.Result)
+	}
+	if len(session.Shots) > 0 {
+		known := boards.target()
+		app.SetStates(opponentBoard, known.states())
+		hints.update(known)
+	}
 	var statusMapMutex sync.Mutex
 	for {
 		select {
@@ -271,12 +308,15 @@ func opponentBoardOperations(ctx context.Context, playerToken string, opponentBo
 
 			for {
 				time.Sleep(200 * time.Millisecond)
+				if ctx.Err() != nil {
+					return
+				}
 				// Get game status
-				gameStatus, err = retryOnError(ui, func() (string, error) {
-					return GetGameStatus(playerToken)
+				gameStatus, err = retryOnError(func() (string, error) {
+					return app.API.GetGameStatus(playerToken)
 				})
 				if err != nil {
-					ui.Draw(gui.NewText(1, 28, "Error getting game status: "+err.Error(), errorTextConfig))
+					slog.Error("getting game status", "err", err)
 					continue
 				}
 
@@ -285,7 +325,7 @@ func opponentBoardOperations(ctx context.Context, playerToken string, opponentBo
 				err = json.Unmarshal([]byte(gameStatus), &statusMap)
 				statusMapMutex.Unlock()
 				if err != nil {
-					ui.Draw(gui.NewText(0, 0, "Error parsing game status no.%s: "+err.Error(), errorTextConfig))
+					slog.Error("parsing game status", "err", err)
 					continue
 				}
 
@@ -297,41 +337,49 @@ func opponentBoardOperations(ctx context.Context, playerToken string, opponentBo
 				continue
 			}
 
-			// Listen for input
-			listenCtx, cancel := context.WithCancel(context.Background())
-			char := opponentBoard.Listen(listenCtx)
-			cancel()
-			// make row and col from char
-			col := int(char[0] - 'A')
-			var row int
-			if len(char) == 3 {
-				row = 9
-			} else {
-				row = int(char[1] - '1')
-			}
-			// check if the shot was already made
-			found := false
-			for _, coordinate := range shotCoordinates {
-				if coordinate == char {
-					found = true
-					break
-				}
+			// Listen for input, with auto-fire only until the turn is about to run out
+			listenCtx, cancelListen := context.WithCancel(ctx)
+			if timer, ok := statusMap["timer"].(float64); ok && autoFire != nil && timer > 0 {
+				cancelListen()
+				listenCtx, cancelListen = context.WithTimeout(ctx, time.Duration(int(timer)-settings.AutoFireAt)*time.Second)
+			}
+			char := app.ListenBoard(listenCtx, opponentBoard)
+			autoFired := char == "" && ctx.Err() == nil && listenCtx.Err() != nil
+			cancelListen()
+			known := boards.target()
+			if autoFired {
+				char = autoFireShot(autoFire, &known)
+				slog.Info("auto-fire", "coord", char, "strategy", settings.Strategy)
+				gameMessage(app, app.T("game.autoFired", char), app.Theme().HighlightText)
+			}
+			if char == "" {
+				continue
 			}
-			if found {
-				ui.Draw(gui.NewText(24, 2, "You have already fired at this coordinate", errorTextConfig))
+			// check if we already know what is at the coordinate
+			col, row, err := coordToIndex(char)
+			if err != nil {
+				continue
+			}
+			switch known[col][row] {
+			case cellUnknown:
+				gameMessage(app, "", app.Theme().Error)
+			case cellInferredEmpty:
+				gameMessage(app, app.T("game.inferredEmpty", char), app.Theme().Error)
+				continue
+			default:
+				gameMessage(app, app.T("game.alreadyFired"), app.Theme().Error)
 				continue
-			} else {
-				ui.Draw(gui.NewText(24, 2, "                                         ", errorTextConfig))
 			}
 			// get fire response
-			fireResponse, err := retryOnError(ui, func() (string, error) {
-				return FireAtEnemy(playerToken, char)
+			fireResponse, err := retryOnError(func() (string, error) {
+				return app.API.FireAtEnemy(playerToken, char)
 			})
 			if err != nil {
-				ui.Draw(gui.NewText(1, 29, "Error firing at enemy: "+err.Error(), errorTextConfig))
+				// The shot may have reached the server anyway, the cell stays unknown so it can be fired again
+				slog.Error("firing", "coord", char, "err", err)
+				gameMessage(app, app.T("error.fire", err), app.Theme().Error)
 				continue
 			}
-			totalShots++
 
 			// Lock before accessing fireMap
 			fireMapMutex.Lock()
@@ -343,92 +391,50 @@ func opponentBoardOperations(ctx context.Context, playerToken string, opponentBo
 				continue
 			}
 
-			if result, ok := fireMap["result"].(string); ok {
-				// Update board states based on fire response
-				switch result {
-				case "hit":
-					shipsShot = append(shipsShot, char)
-					opponentStates[col][row] = gui.Hit
-					successfulShots++
-				case "sunk":
-					shipsShot = append(shipsShot, char)
-					shipsShotMap := mapShips(shipsShot)
-					for _, ship := range shipsShotMap {
-						for _, coord := range ship.Coords {
-							if coord == char {
-								// Mark all coordinates of the ship as sunk
-								for _, shipCoord := range ship.Coords {
-									col := int(shipCoord[0] - 'A')
-									row, _ := strconv.Atoi(shipCoord[1:])
-									opponentStates[col][row-1] = gui.Sunk
-								}
-								// Mark the surrounding area of the ship as misses
-								for _, shipCoord := range ship.Coords {
-									col := int(shipCoord[0] - 'A')
-									row, _ := strconv.Atoi(shipCoord[1:])
-									// Check the surrounding cells
-									for dCol := -1; dCol <= 1; dCol++ {
-										for dRow := -1; dRow <= 1; dRow++ {
-											newCol := col + dCol
-											newRow := row - 1 + dRow
-
-											if newCol >= 0 && newCol < 10 && newRow >= 0 && newRow < 10 && opponentStates[newCol][newRow] != gui.Sunk {
-												opponentStates[newCol][newRow] = gui.Miss
-												// Convert the coordinates back to the string format
-												missCoord := fmt.Sprintf("%c%d", 'A'+newCol, newRow+1)
-												// Add the coordinates to the shotCoordinates slice
-												shotCoordinates = append(shotCoordinates, missCoord)
-											}
-										}
-									}
-								}
-							}
-						}
-					}
-					successfulShots++
-
-				case "miss":
-					opponentStates[col][row] = gui.Miss
-				}
-			} else {
-				ui.Draw(gui.NewText(1, 29, "Error parsing fire response", errorTextConfig))
+			result, ok := fireMap["result"].(string)
+			if !ok {
+				slog.Error("unexpected fire response", "coord", char, "response", fireResponse)
 				continue
 			}
-			// Display fire accuracy
-			var shotAccuracyText = "Shot accuracy: N/A"
-			if totalShots > 0 {
-				shotAccuracy := (float64(successfulShots) / float64(totalShots)) * 100
-				shotAccuracyText = fmt.Sprintf("Shot accuracy: %.2f%%", shotAccuracy)
+			recorder.shot("player", char, result)
+			if autoFire != nil {
+				autoFire.Record(char, result)
 			}
-			ui.Draw(gui.NewText(1, 26, shotAccuracyText, defaultText))
-			shotCoordinates = append(shotCoordinates, char)
-			opponentBoard.SetStates(opponentStates)
+			hints.record(char, result)
+			// Sunk ships are only marked with the cells around them once we know where they are
+			sunkShip, known := boards.fired(char, result)
+			slog.Debug("shot", "coord", char, "result", result, "sunk", len(sunkShip))
+			panel.fired(result, len(sunkShip))
+			app.SetStates(opponentBoard, known.states())
+			hints.update(known)
+			session.Shots = append(session.Shots, sessionShot{Coord: char, Result: result})
+			app.saveSession(session)
 		}
 		time.Sleep(100 * time.Millisecond)
 	}
 }
 
-func playerBoardOperations(ctx context.Context, playerToken string, playerBoard *gui.Board, playerStates [10][10]gui.State, ui *gui.GUI, shipStatus map[string]bool, dataCoords []string) {
+func playerBoardOperations(ctx context.Context, playerToken string, playerBoard *gui.Board, app *App, boards *boardSync, recorder *gameRecorder, panel *gamePanel) {
 	for {
 		select {
 		case <-ctx.Done(): // cancel context when the game ends
 			return
 		default:
-			processOpponentShots(playerToken, playerStates, ui, shipStatus, playerBoard, dataCoords)
+			processOpponentShots(ctx, playerToken, app, playerBoard, boards, recorder, panel)
 
 		}
 	}
 }
 
-func processOpponentShots(playerToken string, playerStates [10][10]gui.State, ui *gui.GUI, shipStatus map[string]bool, playerBoard *gui.Board, dataCoords []string) {
-	for {
+func processOpponentShots(ctx context.Context, playerToken string, app *App, playerBoard *gui.Board, boards *boardSync, recorder *gameRecorder, panel *gamePanel) {
+	for ctx.Err() == nil {
 		time.Sleep(200 * time.Millisecond)
 
-		gameStatus, err := retryOnError(ui, func() (string, error) {
-			return GetGameStatus(playerToken)
+		gameStatus, err := retryOnError(func() (string, error) {
+			return app.API.GetGameStatus(playerToken)
 		})
 		if err != nil {
-			ui.Draw(gui.NewText(1, 28, "Error getting game status: "+err.Error(), errorText))
+			slog.Error("getting game status", "err", err)
 			return
 		}
 
@@ -436,7 +442,7 @@ func processOpponentShots(playerToken string, playerStates [10][10]gui.State, ui
 
 		err = json.Unmarshal([]byte(gameStatus), &statusMap)
 		if err != nil {
-			ui.Draw(gui.NewText(1, 28, "Error parsing game status: "+err.Error(), errorText))
+			slog.Error("parsing game status", "err", err)
 			return
 		}
 
@@ -444,78 +450,40 @@ func processOpponentShots(playerToken string, playerStates [10][10]gui.State, ui
 		if !ok || len(oppShots) == 0 {
 			break // No more shots to process
 		}
-
-		ships := mapShips(dataCoords)
-
+		shots := make([]string, 0, len(oppShots))
 		for _, shot := range oppShots {
 			if coord, isString := shot.(string); isString {
-				col := int(coord[0] - 'A')
-				var row int
-				if len(coord) == 3 {
-					row = 9
-				} else {
-					row = int(coord[1] - '1')
-				}
-
-				isHit := false
-				for _, staticCoord := range dataCoords { // Check if the shot is a hit
-					if staticCoord == coord {
-						isHit = true
-						break
-					}
-				}
-
-				if isHit {
-					isSinglePieceShip := false
-					hitShip := Ship{}
-					for _, ship := range ships {
-
-						for _, shipCoord := range ship.Coords {
-							if shipCoord == coord {
-								hitShip = ship
-								if len(ship.Coords) == 1 {
-									isSinglePieceShip = true
-								}
-								break
-							}
-						}
-					}
+				shots = append(shots, coord)
+			}
+		}
 
-					if isSinglePieceShip {
-						playerStates[col][row] = gui.Sunk
-					} else {
-						playerStates[col][row] = gui.Hit
-						allPartsHit := true
-						for _, shipCoord := range hitShip.Coords {
-							shipCol := int(shipCoord[0] - 'A')
-							shipRow := int(shipCoord[1] - '1')
-							if playerStates[shipCol][shipRow] != gui.Hit {
-								allPartsHit = false
-								break
-							}
-						}
-						if allPartsHit {
-							// Iterate through ship coordinates to mark them as sunk
-							for _, shipCoord := range hitShip.Coords {
-								shipCol := int(shipCoord[0] - 'A')
-								shipRow := int(shipCoord[1] - '1')
-								playerStates[shipCol][shipRow] = gui.Sunk
-								shipStatus[shipCoord] = true
-							}
-						}
-					}
-				} else {
-					playerStates[col][row] = gui.Miss
-				}
+		// The whole board is rebuilt from the server data every time, so anything we drew
+		// wrong before is corrected and the player is told where
+		playerStates, results, sunkSizes, diverged := boards.rebuild(shots)
+		if len(diverged) > 0 {
+			slog.Warn("our board diverged from the server, redrawn from server data", "cells", diverged)
+			gameMessage(app, app.T("game.playerDiverged", strings.Join(diverged, " ")), app.Theme().Error)
+		}
+		hits := 0
+		for i, result := range results {
+			recorder.opponentShot(i, shots[i], result)
+			if result != "miss" {
+				hits++
 			}
 		}
+		panel.received(len(shots), hits, sunkSizes)
+
 		// Update the player board with the new states
-		playerBoard.SetStates(playerStates)
+		app.SetStates(playerBoard, playerStates)
 		time.Sleep(100 * time.Millisecond)
 	}
 }
 
-func displayGameStatus(ctx context.Context, playerToken string, ui *gui.GUI, cancel context.CancelFunc) {
+func displayGameStatus(ctx context.Context, playerToken string, app *App, cancel context.CancelFunc, recorder *gameRecorder, panel *gamePanel) {
+	settings := app.Profile().Game
+	warnings := turnTimer{warnings: settings.Warnings}
+	lastStatus := ""
+	turnTimeLeft := -1 // timer of the previous poll, to tell a timeout from a finished game
 	for {
 		select {
 		case <-ctx.Done(): // cancel context when the game ends
@@ -523,18 +491,22 @@ func displayGameStatus(ctx context.Context, playerToken string, ui *gui.GUI, can
 		default:
 			time.Sleep(200 * time.Millisecond)
 
-			gameStatus, err := retryOnError(ui, func() (string, error) {
-				return GetGameStatus(playerToken)
+			gameStatus, err := retryOnError(func() (string, error) {
+				return app.API.GetGameStatus(playerToken)
 			})
 			if err != nil {
-				ui.Draw(gui.NewText(1, 28, "Error getting game status: "+err.Error(), errorText))
+				slog.Error("getting game status", "err", err)
+				recorder.end("", "error")
+				cancel()
 				return
 			}
 
 			var statusMap map[string]interface{}
 			err = json.Unmarshal([]byte(gameStatus), &statusMap)
 			if err != nil {
-				ui.Draw(gui.NewText(1, 28, "Error getting game status: "+err.Error(), errorText))
+				slog.Error("getting game status", "err", err)
+				recorder.end("", "error")
+				cancel()
 				return
 			}
 
@@ -542,69 +514,94 @@ func displayGameStatus(ctx context.Context, playerToken string, ui *gui.GUI, can
 			gameStatusStr, gameStatusExists := statusMap["game_status"].(string)
 			lastGameStatus, lastGameStatusExists := statusMap["last_game_status"].(string)
 
+			if gameStatusStr != lastStatus {
+				slog.Info("game status changed", "from", lastStatus, "to", gameStatusStr)
+				lastStatus = gameStatusStr
+			}
+
 			// timer
 			timerValue, timerExists := statusMap["timer"].(float64)
 			if timerExists {
-				timerText := fmt.Sprintf("Timer: %.0f", timerValue)
-				ui.Draw(gui.NewText(43, 1, timerText, defaultText))
+				recorder.timer(int(timerValue))
+				timerRect := layout.Game().Status.Place(layout.Box{W: 12, H: 1}, layout.Top)
+				app.Draw(gui.NewText(timerRect.X, timerRect.Y, fitText(app.T("game.timer", timerValue), timerRect.W), app.Theme().Text))
 			}
 
-			// should fire text
-			if gameStatusExists && gameStatusStr != "ended" {
-				shouldFireText := "Should fire: No!"
-				shouldFire, shouldFireExists := statusMap["should_fire"].(bool)
-				if shouldFireExists && shouldFire {
-					shouldFireText = "Should fire: Yes"
+			// turn indicator, warned when our turn is running out
+			if gameStatusExists {
+				shouldFire, _ := statusMap["should_fire"].(bool)
+				ended := gameStatusStr == "ended"
+				panel.turn(shouldFire, ended)
+				if timerExists {
+					if threshold := warnings.update(shouldFire && !ended, int(timerValue)); threshold > 0 {
+						slog.Debug("turn timer warning", "threshold", threshold, "timer", timerValue)
+						panel.warn(app.T("game.hurry", int(timerValue)))
+						if settings.Bell {
+							app.Bell()
+						}
+					}
 				}
-				ui.Draw(gui.NewText(40, 0, shouldFireText, defaultText))
 			}
 
-			// Display user details
-			userNick := DefaultGameInitData.Nick
-			ui.Draw(gui.NewText(2, 27, "User Nick: "+userNick, defaultText))
-			userDesc := DefaultGameInitData.Desc
-			userDescChunks := splitIntoChunks(userDesc, 25)
-			for i, chunk := range userDescChunks {
-				ui.Draw(gui.NewText(2, 28+i, chunk, defaultText))
-			}
+			// Display user details under the panel statistics of our side
+			g := layout.Game()
+			profile := app.Profile()
+			drawPlayerDetails(app, g.PlayerInfo, app.T("game.userNick", profile.Nick), profile.Desc)
 
 			// Display opponent details
-			opponent, opponentExists := statusMap["opponent"].(string)
-			if opponentExists {
-				ui.Draw(gui.NewText(60, 27, "Opponent Nick: "+opponent, defaultText))
-			}
+			opponent, _ := statusMap["opponent"].(string)
 
-			oppDescValue, err := retryOnError(ui, func() (string, error) {
-				return GetGameDescription(playerToken)
+			oppDescValue, err := retryOnError(func() (string, error) {
+				return app.API.GetGameDescription(playerToken)
 			})
 			if err != nil {
-				ui.Draw(gui.NewText(1, 28, "Error getting game status: "+err.Error(), errorText))
+				slog.Error("getting game status", "err", err)
+				recorder.end("", "error")
+				cancel()
 				return
 			}
-			// display opp desc as chunks
-			oppDescChunks := splitIntoChunks(oppDescValue, 25)
-			for i, chunk := range oppDescChunks {
-				ui.Draw(gui.NewText(60, 28+i, chunk, defaultText))
+			if opponent != "" {
+				drawPlayerDetails(app, g.OpponentInfo, app.T("game.opponentNick", opponent), oppDescValue)
 			}
 
 			// Display end game status, cancel goroutines and return to main menu
-			if gameStatusExists && lastGameStatusExists && gameStatusStr == "ended" && lastGameStatus == "lose" {
-				ui.Draw(gui.NewText(3, 1, "Unfortunately You Lose", errorText))
-				cancel()
-				time.Sleep(5 * time.Second)
-				go MainMenu(ui)
-
-			} else if gameStatusStr == "ended" && lastGameStatus == "win" {
-				win := gui.NewTextConfig()
-				win.FgColor = gui.Green
-				win.BgColor = gui.Black
-				ui.Draw(gui.NewText(3, 1, "Congratulations You Win", win))
+			if gameStatusExists && gameStatusStr == "ended" {
+				reason := "finished"
+				// The server ends the game when a turn runs out of time
+				if turnTimeLeft >= 0 && turnTimeLeft <= 1 {
+					reason = "timeout"
+				}
+				if lastGameStatusExists && lastGameStatus == "win" {
+					app.Draw(gui.NewText(g.Status.X+2, g.Status.Y, app.T("game.win"), app.Theme().SuccessText))
+				} else {
+					app.Draw(gui.NewText(g.Status.X+2, g.Status.Y, app.T("game.lose"), app.Theme().Error))
+				}
+				slog.Info("game ended", "outcome", lastGameStatus, "reason", reason)
+				recorder.end(lastGameStatus, reason)
 				cancel()
-				time.Sleep(5 * time.Second)
-				go MainMenu(ui)
+				return
+			}
+			if timerExists {
+				turnTimeLeft = int(timerValue)
 			}
-
 		}
 		time.Sleep(100 * time.Millisecond)
 	}
 }
+
+// drawPlayerDetails shows the nick and description of a player in the info column of its side,
+// below the panel statistics. The description is cut to the rows left above the log panel.
+func drawPlayerDetails(app *App, info layout.Rect, nick, desc string) {
+	area := info.Below(info.Y + panelStatRows)
+	if area.H == 0 {
+		return
+	}
+	width := max(area.W-1, 1)
+	app.Draw(gui.NewText(area.X, area.Y, fitText(nick, width), app.Theme().Text))
+	for i, chunk := range splitIntoChunks(desc, width) {
+		if i+1 >= area.H {
+			break
+		}
+		app.Draw(gui.NewText(area.X, area.Y+1+i, chunk, app.Theme().Text))
+	}
+}
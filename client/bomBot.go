@@ -4,47 +4,43 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"math/rand"
 	"sync"
 	"time"
-
-	gui "github.com/s25867/warships-gui/v2"
 )
 
-func bomBotInit(ui *gui.GUI, gameData GameInitData) {
+func bomBotInit(ctx context.Context, app *App, gameData GameInitData) {
 	gameDataBot := GameInitData{
 		Coords:     generateRandomBoard(),
-		Desc:       "Zapewnia wybuchową rozgrywkę!",
+		Desc:       app.T("bombot.desc"),
 		Nick:       "BomBot",
 		TargetNick: gameData.Nick,
 		Wpbot:      false,
 	}
 	//try to initialize the game
-	playerToken, err := retryOnError(ui, func() (string, error) {
-		return InitGame(gameData)
+	playerToken, err := retryOnError(func() (string, error) {
+		return app.API.InitGame(gameData)
 	})
 	if err != nil {
-		ui.Draw(gui.NewText(1, 29, "Error initializing game: "+err.Error()+". Retrying...", errorText))
+		slog.Error("starting a game with BomBot", "err", err)
 	}
 	//try to initialize the game as a bot
-	botToken, err := retryOnError(ui, func() (string, error) {
-		return InitGame(gameDataBot)
+	botToken, err := retryOnError(func() (string, error) {
+		return app.API.InitGame(gameDataBot)
 	})
 	if err != nil {
-		ui.Draw(gui.NewText(1, 29, "Error initializing game: "+err.Error()+". Retrying...", errorText))
+		slog.Error("starting a game with BomBot", "err", err)
 	}
 
-	ui.NewScreen("game" + playerToken)
-	ui.SetScreen("game" + playerToken)
-
-	go waitForStart(ui, playerToken, gameData, context.CancelFunc(func() {}))
-	if !isWaitingForChallenger {
-		// after the game has started, start the shooting loop
-		go bomBotShots(ui, botToken)
+	if !app.WaitingForChallenger() {
+		// the bot keeps shooting until the game ends, even if we leave the game screen
+		go bomBotShots(app, botToken)
 	}
+	app.Nav.Replace(ctx, gameScreen(playerToken, gameData))
 }
 
-func bomBotShots(ui *gui.GUI, botToken string) {
+func bomBotShots(app *App, botToken string) {
 	// Initialize all possible coordinates
 	var fireMapMutex = &sync.Mutex{}
 	var statusMapMutex = &sync.Mutex{}
@@ -64,11 +60,11 @@ func bomBotShots(ui *gui.GUI, botToken string) {
 		var err error
 		var statusMap map[string]interface{}
 		for {
-			gameStatus, err = retryOnError(ui, func() (string, error) {
-				return GetGameStatus(botToken)
+			gameStatus, err = retryOnError(func() (string, error) {
+				return app.API.GetGameStatus(botToken)
 			})
 			if err != nil {
-				ui.Draw(gui.NewText(1, 28, "Error getting game status: "+err.Error(), errorText))
+				slog.Error("getting game status of BomBot", "err", err)
 				continue
 			}
 
@@ -76,12 +72,15 @@ func bomBotShots(ui *gui.GUI, botToken string) {
 			err = json.Unmarshal([]byte(gameStatus), &statusMap)
 			statusMapMutex.Unlock()
 			if err != nil {
-				ui.Draw(gui.NewText(0, 0, "Error parsing game status no.%s: "+err.Error(), errorText))
+				slog.Error("parsing game status of BomBot", "err", err)
 				continue
 			}
 
 			break
 		}
+		if gameStatusStr, _ := statusMap["game_status"].(string); gameStatusStr == "ended" {
+			return
+		}
 		shouldFire, ok := statusMap["should_fire"].(bool)
 		if !ok || !shouldFire {
 			continue
@@ -103,23 +102,24 @@ func bomBotShots(ui *gui.GUI, botToken string) {
 				allCoords = append(allCoords[:randIndex], allCoords[randIndex+1:]...)
 			} else {
 				// If there are no coordinates left, abandon the game
-				ui.Draw(gui.NewText(1, 28, "Error calculating possible ship locations. Surrendering game...", errorText))
-				ui.Draw(gui.NewText(40, 24, "Leaving game...", errorText))
-				_, err := retryOnError(ui, func() (string, error) {
-					return AbandonGame(botToken)
+				slog.Info("BomBot has nothing left to shoot at, surrendering")
+				gameMessage(app, app.T("bombot.surrender"), app.Theme().Error)
+				_, err := retryOnError(func() (string, error) {
+					return app.API.AbandonGame(botToken)
 				})
 				if err != nil {
-					ui.Draw(gui.NewText(25, 24, "Error leaving game: "+err.Error(), errorText))
+					gameMessage(app, app.T("error.leaveGame", err), app.Theme().Error)
 					continue
 				}
+				return
 			}
 		}
 
-		response, err := retryOnError(ui, func() (string, error) {
-			return FireAtEnemy(botToken, randCoord)
+		response, err := retryOnError(func() (string, error) {
+			return app.API.FireAtEnemy(botToken, randCoord)
 		})
 		if err != nil {
-			ui.Draw(gui.NewText(1, 29, "Error firing at enemy: "+err.Error(), errorText))
+			slog.Error("BomBot firing", "err", err)
 			continue
 		}
 
@@ -134,6 +134,7 @@ func bomBotShots(ui *gui.GUI, botToken string) {
 		}
 
 		if result, ok := fireMap["result"].(string); ok {
+			slog.Debug("BomBot shot", "coord", randCoord, "result", result)
 			if result == "hit" {
 				hitShots = append(hitShots, randCoord)
 				botTable = mapShips(hitShots)
@@ -145,7 +146,7 @@ func bomBotShots(ui *gui.GUI, botToken string) {
 							// Check if the surrounding coordinate is in the list of all coordinates
 							if findIndex(allCoords, surrCoord) != -1 {
 								if adjacent, err := isAdjacentShip(surrCoord, ship.Coords, 1); err != nil {
-									ui.Draw(gui.NewText(1, 28, "Error: "+err.Error(), errorText))
+									slog.Error("BomBot looking for ship parts", "err", err)
 								} else if adjacent {
 									// If the surrounding coordinate is adjacent to the ship, add it to the new surrounding area
 									newSurroundingArea = append(newSurroundingArea, surrCoord)
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"BomboweStatki/layout"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	gui "github.com/s25867/warships-gui/v2"
+)
+
+// How many of the latest games against the opponent are listed
+const recentResults = 5
+
+// headToHead is our record against one opponent from locally recorded games
+type headToHead struct {
+	Wins       int
+	Losses     int
+	Unfinished int
+	Recent     []matchSummary // newest first
+}
+
+func computeHeadToHead(matches []matchSummary, opponent string) headToHead {
+	var record headToHead
+	for _, match := range matches {
+		if match.Opponent != opponent {
+			continue
+		}
+		switch match.Outcome {
+		case "win":
+			record.Wins++
+		case "lose":
+			record.Losses++
+		default:
+			record.Unfinished++
+		}
+		if len(record.Recent) < recentResults {
+			record.Recent = append(record.Recent, match)
+		}
+	}
+	return record
+}
+
+// compareScreen shows us and the opponent side by side, when canChallenge is set
+// it also has a button to challenge the opponent
+func compareScreen(opponent string, canChallenge bool) Screen {
+	return Screen{Name: "compare", Enter: func(ctx context.Context, app *App) {
+		comparePlayers(ctx, app, opponent, canChallenge)
+	}}
+}
+
+func comparePlayers(ctx context.Context, app *App, opponent string, canChallenge bool) {
+	compareUi := CompareElements(app, opponent, canChallenge)
+	nick := app.Profile().Nick
+
+	// Server stats side by side
+	var stats [2]PlayerStats
+	for i, player := range []string{nick, opponent} {
+		playerStats, err := app.API.GetPlayerStats(player)
+		if err != nil {
+			// Players that haven't finished a game yet have no stats
+			playerStats = PlayerStats{Nick: player}
+		}
+		stats[i] = playerStats
+	}
+	rows := []struct {
+		label  string // message key
+		values [2]float64
+		format func(v float64) string
+		higher bool // whether a higher value is better
+	}{
+		{"sort.Rank", [2]float64{float64(stats[0].Rank), float64(stats[1].Rank)}, formatInt, false},
+		{"sort.Points", [2]float64{float64(stats[0].Points), float64(stats[1].Points)}, formatInt, true},
+		{"sort.Games", [2]float64{float64(stats[0].Games), float64(stats[1].Games)}, formatInt, true},
+		{"sort.Wins", [2]float64{float64(stats[0].Wins), float64(stats[1].Wins)}, formatInt, true},
+		{"compare.winRate", [2]float64{stats[0].WinRate(), stats[1].WinRate()}, formatPercent, true},
+	}
+	app.Draw(gui.NewText(2, 7, fmt.Sprintf("%-10s %-20.20s %-20.20s", "", nick, opponent), app.Theme().Text))
+	for i, row := range rows {
+		y := 8 + i
+		app.Draw(gui.NewText(2, y, app.T(row.label), app.Theme().Text))
+		for player, value := range row.values {
+			config := app.Theme().Text
+			other := row.values[1-player]
+			// Rank 0 means the player isn't ranked yet
+			if value != other && (value > other) == row.higher && !(row.label == "sort.Rank" && value == 0) {
+				config = app.Theme().SuccessText
+			}
+			app.Draw(gui.NewText(13+player*21, y, row.format(value), config))
+		}
+	}
+
+	// Our record against the opponent
+	analytics, err := loadAnalytics()
+	if err != nil {
+		statusMessage(app, layout.Message(), app.T("error.history", err), app.Theme().Error)
+	}
+	record := computeHeadToHead(analytics.Matches, opponent)
+	app.Draw(gui.NewText(2, 15, app.T("compare.headToHead", record.Wins, record.Losses, record.Unfinished), app.Theme().Text))
+	if len(record.Recent) == 0 {
+		app.Draw(gui.NewText(2, 17, app.T("compare.noGames", opponent), app.Theme().Text))
+	} else {
+		app.Draw(gui.NewText(2, 17, app.T("compare.recent"), app.Theme().Text))
+	}
+	for i, match := range record.Recent {
+		outcome := match.Outcome
+		if outcome == "" {
+			outcome = app.T("replays.unfinished")
+		}
+		line := app.T("compare.match",
+			match.Time.Format("2006-01-02 15:04"), outcome, match.Shots, accuracyText(match.Hits, match.Shots), match.Duration.Round(time.Second))
+		app.Draw(gui.NewText(4, 18+i, line, app.Theme().Text))
+	}
+
+	for ctx.Err() == nil {
+		clicked := app.ListenArea(ctx, compareUi.ButtonArea)
+		switch clicked {
+		case "returnButton":
+			app.Nav.Pop(ctx)
+			return
+		case "challengeButton":
+			gameData := app.GameData()
+			gameData.TargetNick = opponent
+			if err := StartGame(ctx, app, gameData); err != nil {
+				statusMessage(app, layout.Message(), app.T("error.startGame", err), app.Theme().Error)
+				continue
+			}
+			return
+		}
+	}
+}
+
+func formatInt(v float64) string {
+	return strconv.Itoa(int(v))
+}
+
+func formatPercent(v float64) string {
+	return fmt.Sprintf("%.1f%%", v)
+}
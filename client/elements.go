@@ -2,50 +2,50 @@ package client
 
 import (
 	board "BomboweStatki/board"
+	"BomboweStatki/layout"
+	"fmt"
+	"strings"
 
 	gui "github.com/s25867/warships-gui/v2"
 )
 
-// Global text configuration
-var defaultText = gui.NewTextConfig()
+func MainMenuElements(app *App) *MenuUI {
+	area := layout.Content().Pad(2)
+	// Buttons go top to bottom, in more columns if the terminal is short
+	buttons := area.Below(5).Stack(1, layout.Repeat(layout.Box{W: 9, H: 3}, 6)...)
+	topPlayers := area.Below(3)
+	topPlayers.X = layout.Union(buttons...).Right() + 8
 
-func init() {
-	defaultText.FgColor = gui.White
-	defaultText.BgColor = gui.Black
-}
-
-var errorText = gui.NewTextConfig()
-
-func init() {
-	errorText.FgColor = gui.Red
-	errorText.BgColor = gui.Black
-}
-
-func MainMenuElements(ui *gui.GUI) *MenuUI {
-	sectionText := gui.NewText(2, 1, "Bombowe Statki", defaultText)
-	textConfig := gui.NewTextConfig()
-	textConfig.FgColor = gui.Yellow
-	textConfig.BgColor = gui.Black
-	hofText := gui.NewText(30, 1, "Hall of Fame", textConfig)
+	sectionText := gui.NewText(area.X, 1, app.T("menu.title"), app.Theme().Text)
+	hofText := gui.NewText(topPlayers.X, 1, app.T("menu.hallOfFame"), app.Theme().HighlightText)
 	// Action Buttons
 	buttonConfig := gui.NewButtonConfig()
 	buttonConfig.Height = 3
 	buttonConfig.Width = 9
-	buttonConfig.BgColor = gui.Green
-	buttonConfig.FgColor = gui.Black
-	pvpButtton := gui.NewButton(2, 5, "PvP", buttonConfig)
-	buttonConfig.BgColor = gui.Blue
-	botButtton := gui.NewButton(2, 9, "Bot", buttonConfig)
-	buttonConfig.BgColor = gui.Red
-	profileButton := gui.NewButton(2, 13, "Profile", buttonConfig)
+	buttonConfig.BgColor = app.Theme().Success
+	buttonConfig.FgColor = app.Theme().ButtonText
+	pvpButtton := gui.NewButton(buttons[0].X, buttons[0].Y, app.T("menu.pvp"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Primary
+	botButtton := gui.NewButton(buttons[1].X, buttons[1].Y, app.T("menu.bot"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Danger
+	profileButton := gui.NewButton(buttons[2].X, buttons[2].Y, app.T("menu.profile"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Highlight
+	replaysButton := gui.NewButton(buttons[3].X, buttons[3].Y, app.T("menu.replays"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Success
+	quickMatchButton := gui.NewButton(buttons[4].X, buttons[4].Y, app.T("menu.quick"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Primary
+	leaderboardButton := gui.NewButton(buttons[5].X, buttons[5].Y, app.T("menu.ranking"), buttonConfig)
 
 	// Handle Area for buttons
 	buttonMapping := map[string]gui.Spatial{
-		"botButtton":    botButtton,
-		"pvpButtton":    pvpButtton,
-		"profileButton": profileButton,
+		"botButtton":        botButtton,
+		"pvpButtton":        pvpButtton,
+		"profileButton":     profileButton,
+		"replaysButton":     replaysButton,
+		"quickMatchButton":  quickMatchButton,
+		"leaderboardButton": leaderboardButton,
 	}
-	buttonArea := gui.NewHandleArea(buttonMapping)
+	buttonArea := app.NewHandleArea(buttonMapping)
 
 	// Draw all objects
 	drawables := []gui.Drawable{
@@ -55,64 +55,80 @@ func MainMenuElements(ui *gui.GUI) *MenuUI {
 		pvpButtton,
 		botButtton,
 		profileButton,
+		replaysButton,
+		quickMatchButton,
+		leaderboardButton,
 	}
 	for _, drawable := range drawables {
-		ui.Draw(drawable)
+		app.Draw(drawable)
 	}
 
 	return &MenuUI{
-		Ui:            ui,
+		Ui:            app,
 		pvpButtton:    pvpButtton,
 		botButton:     botButtton,
 		refreshButton: profileButton,
 		ButtonArea:    buttonArea,
+		TopPlayers:    topPlayers,
 	}
 }
 
 type botMenuUI struct {
-	Ui         *gui.GUI
+	Ui         UI
 	ButtonArea *gui.HandleArea
 	Drawable   []gui.Drawable
 }
 type ProfileUI struct {
-	Ui               *gui.GUI
+	Ui               UI
 	returnButton     *gui.Button
 	ButtonArea       *gui.HandleArea
 	UsernameField    *gui.TextInput
 	DescriptionField *gui.TextInput
 	EditNameButton   *gui.Button
 	EditDescButton   *gui.Button
+	Stats            layout.Rect // where stats of the player go
 }
 
-func ProfileElements(ui *gui.GUI) *ProfileUI {
-	sectionText := gui.NewText(2, 1, "Edit your profile", defaultText)
-	currentName := gui.NewText(80, 5, "Current username: "+DefaultGameInitData.Nick, defaultText)
-	currentDesc := gui.NewText(80, 6, "Current description: "+DefaultGameInitData.Desc, defaultText)
+func ProfileElements(app *App) *ProfileUI {
+	profile := app.Profile()
+	// Edit buttons, the board and details of the player in three columns
+	area := layout.Content().Pad(2)
+	columns := area.Below(3).Columns(22, layout.BoardWidth+6, 0)
+	button := layout.Box{W: 20, H: 3}
+	editButtons := columns[0].Below(9).Stack(1, layout.Repeat(button, 5)...)
+	details := columns[2]
+	detailButtons := details.Below(19).Stack(1, button, button, button, button)
+
+	sectionText := gui.NewText(area.X, 1, app.T("profile.title"), app.Theme().Text)
+	currentName := gui.NewText(details.X, 5, app.T("profile.currentName", profile.Nick), app.Theme().Text)
+	currentDesc := gui.NewText(details.X, 6, app.T("profile.currentDesc", profile.Desc), app.Theme().Text)
 
 	// Action Buttons
 	buttonConfig := gui.NewButtonConfig()
 	buttonConfig.Height = 3
 	buttonConfig.Width = 20
-	buttonConfig.FgColor = gui.Black
-	buttonConfig.BgColor = gui.Red
-	returnButton := gui.NewButton(2, 9, "Return", buttonConfig)
-	_, h := returnButton.Size()
-	buttonConfig.BgColor = gui.Green
-	editNameButton := gui.NewButton(2, h+10, "Edit Name", buttonConfig)
-	_, h = editNameButton.Size()
-	editDescButton := gui.NewButton(2, 2*h+11, "Edit Description", buttonConfig)
-	_, h = editDescButton.Size()
-	buttonConfig.BgColor = gui.Blue
-	editBoardButton := gui.NewButton(2, 3*+h+12, "Edit Board Layout", buttonConfig)
-	_, h = editBoardButton.Size()
-	randomBoardButton := gui.NewButton(2, 4*h+13, "Get Random Board", buttonConfig)
+	buttonConfig.FgColor = app.Theme().ButtonText
+	buttonConfig.BgColor = app.Theme().Danger
+	returnButton := gui.NewButton(editButtons[0].X, editButtons[0].Y, app.T("common.return"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Success
+	editNameButton := gui.NewButton(editButtons[1].X, editButtons[1].Y, app.T("profile.editName"), buttonConfig)
+	editDescButton := gui.NewButton(editButtons[2].X, editButtons[2].Y, app.T("profile.editDesc"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Primary
+	editBoardButton := gui.NewButton(editButtons[3].X, editButtons[3].Y, app.T("profile.editBoard"), buttonConfig)
+	randomBoardButton := gui.NewButton(editButtons[4].X, editButtons[4].Y, app.T("profile.randomBoard"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Highlight
+	historyButton := gui.NewButton(detailButtons[0].X, detailButtons[0].Y, app.T("profile.history"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Primary
+	themeButton := gui.NewButton(detailButtons[1].X, detailButtons[1].Y, app.T("profile.theme", app.Theme().Name), buttonConfig)
+	languageButton := gui.NewButton(detailButtons[2].X, detailButtons[2].Y, app.T("profile.language"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Highlight
+	settingsButton := gui.NewButton(detailButtons[3].X, detailButtons[3].Y, app.T("profile.settings"), buttonConfig)
 
 	//board
-	boardText := gui.NewText(32, 3, "Your current board layout", defaultText)
-	boardStates, _, _, _ := board.Config(DefaultGameInitData.Coords)
-	boardConfig := gui.NewBoardConfig()
-	boardLayout := gui.NewBoard(28, 5, boardConfig)
-	boardLayout.SetStates(boardStates)
+	boardText := gui.NewText(columns[1].X+8, columns[1].Y, app.T("profile.board"), app.Theme().Text)
+	boardStates, _, _, _ := board.Config(profile.Coords)
+	boardLayout := gui.NewBoard(columns[1].X+4, columns[1].Y+2, app.Theme().BoardConfig())
+	app.SetStates(boardLayout, boardStates)
 
 	// Handle Area for buttons
 	buttonMapping := map[string]gui.Spatial{
@@ -121,8 +137,12 @@ func ProfileElements(ui *gui.GUI) *ProfileUI {
 		"editNameButton":    editNameButton,
 		"editDescButton":    editDescButton,
 		"randomBoardButton": randomBoardButton,
+		"historyButton":     historyButton,
+		"themeButton":       themeButton,
+		"languageButton":    languageButton,
+		"settingsButton":    settingsButton,
 	}
-	buttonArea := gui.NewHandleArea(buttonMapping)
+	buttonArea := app.NewHandleArea(buttonMapping)
 
 	// Draw all objects
 	drawables := []gui.Drawable{
@@ -136,55 +156,58 @@ func ProfileElements(ui *gui.GUI) *ProfileUI {
 		editNameButton,
 		editDescButton,
 		randomBoardButton,
+		historyButton,
+		themeButton,
+		languageButton,
+		settingsButton,
 		boardLayout,
 	}
 	for _, drawable := range drawables {
-		ui.Draw(drawable)
+		app.Draw(drawable)
 	}
 
 	return &ProfileUI{
-		Ui:             ui,
+		Ui:             app,
 		returnButton:   returnButton,
 		ButtonArea:     buttonArea,
 		EditNameButton: editNameButton,
 		EditDescButton: editDescButton,
+		Stats:          details.Below(11),
 	}
 }
 
 type LobbyUI struct {
-	Ui         *gui.GUI
-	ButtonArea *gui.HandleArea
-	Drawable   []gui.Drawable
+	Ui              UI
+	ButtonArea      *gui.HandleArea
+	Drawable        []gui.Drawable
+	KeepAliveButton *gui.Button
+	Players         layout.Rect // where the list of players goes
+	Status          layout.Rect // rows above the buttons for notices, the keep-alive and the timer
 }
 
-func LobbyElements(ui *gui.GUI, lobbyInfo []Player) *LobbyUI {
-	sectionText := gui.NewText(2, 1, "Lobby", defaultText)
+func LobbyElements(app *App) *LobbyUI {
+	area := layout.Content().Pad(2)
+	// Action buttons in a row, wrapped if the terminal is narrow
+	buttons := area.Below(5).Flow(2, layout.Box{W: 9, H: 3}, layout.Box{W: 9, H: 3}, layout.Box{W: 12, H: 3}, layout.Box{W: 12, H: 3}, layout.Box{W: 16, H: 3})
+	players := area.Below(layout.Union(buttons...).Bottom() + 3)
+
+	sectionText := gui.NewText(area.X, 1, app.T("lobby.title"), app.Theme().Text)
 
 	// Action Buttons
 	buttonConfig := gui.NewButtonConfig()
 	buttonConfig.Height = 3
 	buttonConfig.Width = 9
-	buttonConfig.FgColor = gui.Black
-	buttonConfig.BgColor = gui.Green
-	refreshButton := gui.NewButton(2, 5, "Refresh", buttonConfig)
-	x, _ := refreshButton.Position()
-	w, _ := refreshButton.Size()
-	buttonConfig.BgColor = gui.Red
-	returnButton := gui.NewButton(x+w+2, 5, "Return", buttonConfig)
-	x, _ = returnButton.Position()
-	w, _ = returnButton.Size()
-	buttonConfig.BgColor = gui.Blue
+	buttonConfig.FgColor = app.Theme().ButtonText
+	buttonConfig.BgColor = app.Theme().Success
+	refreshButton := gui.NewButton(buttons[0].X, buttons[0].Y, app.T("lobby.refresh"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Danger
+	returnButton := gui.NewButton(buttons[1].X, buttons[1].Y, app.T("common.return"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Primary
 	buttonConfig.Width = 12
-	addYourselfButton := gui.NewButton(x+w+2, 5, "Join lobby", buttonConfig)
-	buttonConfig.BgColor = gui.Green
-	x, _ = addYourselfButton.Position()
-	w, _ = addYourselfButton.Size()
-	resetLobbyTimerButton := gui.NewButton(x+w+2, 5, "Reset Timer", buttonConfig)
-
-	// Check if lobby is empty
-	if len(lobbyInfo) == 0 {
-		ui.Draw(gui.NewText(2, 11, "Lobby is empty", defaultText))
-	}
+	addYourselfButton := gui.NewButton(buttons[2].X, buttons[2].Y, app.T("lobby.join"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Success
+	resetLobbyTimerButton := gui.NewButton(buttons[3].X, buttons[3].Y, app.T("lobby.resetTimer"), buttonConfig)
+	keepAliveButton := KeepAliveButton(app, buttons[4].X, buttons[4].Y, app.Profile().KeepAlive)
 
 	// Handle Area for buttons
 	buttonMapping := map[string]gui.Spatial{
@@ -192,32 +215,9 @@ func LobbyElements(ui *gui.GUI, lobbyInfo []Player) *LobbyUI {
 		"returnButton":          returnButton,
 		"addYourselfButton":     addYourselfButton,
 		"resetLobbyTimerButton": resetLobbyTimerButton,
+		"keepAliveButton":       keepAliveButton,
 	}
-
-	x, y := 2, 11
-	for _, player := range lobbyInfo {
-		if player.GameStatus == "waiting" {
-			buttonConfig.Width = max(len(player.Nick)+2, 9)
-			opponentButton := gui.NewButton(x, y, player.Nick, buttonConfig)
-
-			// Draw the player's nick
-			nickText := gui.NewText(x, y, player.Nick, defaultText)
-			ui.Draw(nickText)
-
-			// Add the opponent button to the buttonMapping
-			buttonMapping[player.Nick] = opponentButton
-
-			// Update x and y for the next button
-			x += buttonConfig.Width + 2
-			if (x + buttonConfig.Width) > 80 {
-				x = 2
-				y += 4
-			}
-		}
-	}
-
-	// Update Handle Area to include all buttons
-	buttonArea := gui.NewHandleArea(buttonMapping)
+	buttonArea := app.NewHandleArea(buttonMapping)
 
 	// Draw all objects
 	drawables := []gui.Drawable{
@@ -227,57 +227,132 @@ func LobbyElements(ui *gui.GUI, lobbyInfo []Player) *LobbyUI {
 		returnButton,
 		addYourselfButton,
 		resetLobbyTimerButton,
+		keepAliveButton,
+	}
+	for _, drawable := range drawables {
+		app.Draw(drawable)
 	}
 
-	for _, player := range lobbyInfo {
+	return &LobbyUI{
+		Drawable:        drawables,
+		Ui:              app,
+		ButtonArea:      buttonArea,
+		KeepAliveButton: keepAliveButton,
+		Players:         players,
+		Status:          layout.MenuStatus(),
+	}
+}
+
+// KeepAliveButton toggles refreshing our place in the lobby automatically, it's drawn again when clicked
+func KeepAliveButton(app *App, x, y int, on bool) *gui.Button {
+	buttonConfig := gui.NewButtonConfig()
+	buttonConfig.Height = 3
+	buttonConfig.Width = 16
+	buttonConfig.FgColor = app.Theme().ButtonText
+	buttonConfig.BgColor = app.Theme().Highlight
+	if on {
+		return gui.NewButton(x, y, app.T("lobby.keepAliveOn"), buttonConfig)
+	}
+	return gui.NewButton(x, y, app.T("lobby.keepAliveOff"), buttonConfig)
+}
+
+// LobbyPlayerElements draws a button for every player in the lobby with their game status below.
+// Players waiting for a game can be challenged, newcomers are highlighted.
+// The returned drawables are removed when the list of players changes.
+func LobbyPlayerElements(app *App, area layout.Rect, lobbyInfo []Player, newcomers map[string]bool) *LobbyUI {
+	var drawables []gui.Drawable
+	buttonMapping := map[string]gui.Spatial{}
+
+	// Check if lobby is empty
+	if len(lobbyInfo) == 0 {
+		drawables = append(drawables, gui.NewText(area.X, area.Y, app.T("lobby.empty"), app.Theme().Text))
+	}
+
+	buttonConfig := gui.NewButtonConfig()
+	buttonConfig.Height = 3
+	buttonConfig.FgColor = app.Theme().ButtonText
+
+	// Rows are 5 cells apart, the status goes in the gap below a button
+	boxes := make([]layout.Box, len(lobbyInfo))
+	for i, player := range lobbyInfo {
+		boxes[i] = layout.Box{W: max(len(player.Nick)+2, 12), H: 3}
+	}
+	cells := area.Flow(2, boxes...)
+
+	for i, player := range lobbyInfo {
+		x, y := cells[i].X, cells[i].Y
+		buttonConfig.Width = cells[i].W
+		switch {
+		case newcomers[player.Nick]:
+			buttonConfig.BgColor = app.Theme().Highlight
+		case player.GameStatus == "waiting":
+			buttonConfig.BgColor = app.Theme().Success
+		default:
+			buttonConfig.BgColor = app.Theme().Muted
+		}
+		playerButton := gui.NewButton(x, y, player.Nick, buttonConfig)
+		status := player.GameStatus
+		if newcomers[player.Nick] {
+			status = app.T("lobby.newcomer", status)
+		}
+		drawables = append(drawables, playerButton, gui.NewText(x, y+3, status, app.Theme().Text))
+
+		// Only waiting players can be challenged
 		if player.GameStatus == "waiting" {
-			opponentButton := buttonMapping[player.Nick].(gui.Drawable)
-			drawables = append(drawables, opponentButton)
+			buttonMapping[player.Nick] = playerButton
 		}
 	}
 
+	buttonArea := app.NewHandleArea(buttonMapping)
+	drawables = append([]gui.Drawable{buttonArea}, drawables...)
 	for _, drawable := range drawables {
-		ui.Draw(drawable)
+		app.Draw(drawable)
 	}
 
 	return &LobbyUI{
 		Drawable:   drawables,
-		Ui:         ui,
+		Ui:         app,
 		ButtonArea: buttonArea,
 	}
 }
 
 type MenuUI struct {
-	Ui            *gui.GUI
+	Ui            UI
 	pvpButtton    *gui.Button
 	botButton     *gui.Button
 	refreshButton *gui.Button
 	ButtonArea    *gui.HandleArea
+	TopPlayers    layout.Rect // where the hall of fame goes
 }
 
-func BotElements(ui *gui.GUI) *botMenuUI {
+func BotElements(app *App) *botMenuUI {
+	area := layout.Content().Pad(2)
+	buttons := area.Below(5).Columns(12, 0)[1].Stack(1, layout.Repeat(layout.Box{W: 10, H: 3}, 4)...)
 
-	sectionText := gui.NewText(2, 2, "Pick a bot you want to figth against!", defaultText)
+	sectionText := gui.NewText(area.X, 2, app.T("bot.title"), app.Theme().Text)
 
 	buttonConfig := gui.NewButtonConfig()
 	buttonConfig.Height = 3
 	buttonConfig.Width = 10
-	buttonConfig.FgColor = gui.Black
-	buttonConfig.BgColor = gui.Green
+	buttonConfig.FgColor = app.Theme().ButtonText
+	buttonConfig.BgColor = app.Theme().Success
 	buttonConfig.WithBorder = true
-	wpBotButton := gui.NewButton(14, 5, "wpBot", buttonConfig)
-	buttonConfig.BgColor = gui.Blue
-	bomBotButton := gui.NewButton(14, 9, "bomBot", buttonConfig)
-	buttonConfig.BgColor = gui.Red
-	returnButton := gui.NewButton(14, 13, "Return", buttonConfig)
+	wpBotButton := gui.NewButton(buttons[0].X, buttons[0].Y, app.T("bot.wpBot"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Primary
+	bomBotButton := gui.NewButton(buttons[1].X, buttons[1].Y, app.T("bot.bomBot"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Danger
+	returnButton := gui.NewButton(buttons[2].X, buttons[2].Y, app.T("common.return"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Highlight
+	spectateButton := gui.NewButton(buttons[3].X, buttons[3].Y, app.T("bot.watch"), buttonConfig)
 
 	buttonMapping := map[string]gui.Spatial{
-		"wpBotButton":  wpBotButton,
-		"bomBotButton": bomBotButton,
-		"returnButton": returnButton,
+		"wpBotButton":    wpBotButton,
+		"bomBotButton":   bomBotButton,
+		"returnButton":   returnButton,
+		"spectateButton": spectateButton,
 	}
 
-	buttonArea := gui.NewHandleArea(buttonMapping)
+	buttonArea := app.NewHandleArea(buttonMapping)
 
 	drawables := []gui.Drawable{
 		sectionText,
@@ -285,15 +360,727 @@ func BotElements(ui *gui.GUI) *botMenuUI {
 		wpBotButton,
 		bomBotButton,
 		returnButton,
+		spectateButton,
 	}
 
 	for _, drawable := range drawables {
-		ui.Draw(drawable)
+		app.Draw(drawable)
 	}
 
 	return &botMenuUI{
 		Drawable:   drawables,
-		Ui:         ui,
+		Ui:         app,
+		ButtonArea: buttonArea,
+	}
+}
+
+type EditorUI struct {
+	Ui         UI
+	Board      *gui.Board
+	ButtonArea *gui.HandleArea
+	Status     layout.Rect   // two rows above the board for the status and errors
+	Counters   []layout.Rect // ships left next to the palette, biggest size first
+}
+
+func EditorElements(app *App) *EditorUI {
+	// The board is on the left, the palette, counters and editing buttons in columns right of it
+	area := layout.Content().Pad(1)
+	boardRect := area.Below(3).Place(layout.Box{W: layout.BoardWidth, H: layout.BoardHeight}, layout.TopLeft)
+	tools := area.Below(boardRect.Y)
+	tools.W = max(tools.Right()-boardRect.Right()-4, 0)
+	tools.X = boardRect.Right() + 4
+	columns := tools.Columns(12, 1, 12, 1, 12)
+	palette := columns[0].Stack(1, layout.Repeat(layout.Box{W: 12, H: 3}, 6)...)
+	edit := columns[4].Stack(1, layout.Repeat(layout.Box{W: 12, H: 3}, 6)...)
+
+	sectionText := gui.NewText(area.X+1, 0, app.T("editor.title"), app.Theme().Text)
+	helpText := gui.NewText(tools.X, area.Y, app.T("editor.help"), app.Theme().Text)
+	editorBoard := gui.NewBoard(boardRect.X, boardRect.Y, app.Theme().BoardConfig())
+
+	// Ship palette
+	buttonConfig := gui.NewButtonConfig()
+	buttonConfig.Height = 3
+	buttonConfig.Width = 12
+	buttonConfig.FgColor = app.Theme().ButtonText
+	buttonConfig.BgColor = app.Theme().Primary
+	ship4Button := gui.NewButton(palette[0].X, palette[0].Y, app.T("editor.size", 4), buttonConfig)
+	ship3Button := gui.NewButton(palette[1].X, palette[1].Y, app.T("editor.size", 3), buttonConfig)
+	ship2Button := gui.NewButton(palette[2].X, palette[2].Y, app.T("editor.size", 2), buttonConfig)
+	ship1Button := gui.NewButton(palette[3].X, palette[3].Y, app.T("editor.size", 1), buttonConfig)
+
+	// Editing buttons
+	buttonConfig.BgColor = app.Theme().Success
+	rotateButton := gui.NewButton(edit[0].X, edit[0].Y, app.T("editor.rotate"), buttonConfig)
+	dropButton := gui.NewButton(edit[1].X, edit[1].Y, app.T("editor.drop"), buttonConfig)
+	undoButton := gui.NewButton(edit[2].X, edit[2].Y, app.T("editor.undo"), buttonConfig)
+	redoButton := gui.NewButton(edit[3].X, edit[3].Y, app.T("editor.redo"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Highlight
+	autoButton := gui.NewButton(palette[4].X, palette[4].Y, app.T("editor.autoFill"), buttonConfig)
+	clearButton := gui.NewButton(edit[4].X, edit[4].Y, app.T("common.clear"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Success
+	saveButton := gui.NewButton(palette[5].X, palette[5].Y, app.T("common.save"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Danger
+	returnButton := gui.NewButton(edit[5].X, edit[5].Y, app.T("common.return"), buttonConfig)
+
+	counters := make([]layout.Rect, 4)
+	for i := range counters {
+		counters[i] = layout.Rect{X: columns[2].X, Y: palette[i].Y + 1, W: columns[2].W, H: 1}
+	}
+
+	buttonMapping := map[string]gui.Spatial{
+		"ship4Button":  ship4Button,
+		"ship3Button":  ship3Button,
+		"ship2Button":  ship2Button,
+		"ship1Button":  ship1Button,
+		"rotateButton": rotateButton,
+		"dropButton":   dropButton,
+		"undoButton":   undoButton,
+		"redoButton":   redoButton,
+		"autoButton":   autoButton,
+		"clearButton":  clearButton,
+		"saveButton":   saveButton,
+		"returnButton": returnButton,
+	}
+	buttonArea := app.NewHandleArea(buttonMapping)
+
+	drawables := []gui.Drawable{
+		sectionText,
+		helpText,
+		editorBoard,
+		buttonArea,
+		ship4Button,
+		ship3Button,
+		ship2Button,
+		ship1Button,
+		rotateButton,
+		dropButton,
+		undoButton,
+		redoButton,
+		autoButton,
+		clearButton,
+		saveButton,
+		returnButton,
+	}
+	for _, drawable := range drawables {
+		app.Draw(drawable)
+	}
+
+	return &EditorUI{
+		Ui:         app,
+		Board:      editorBoard,
+		ButtonArea: buttonArea,
+		Status:     layout.Rect{X: area.X, Y: area.Y, W: max(tools.X-area.X-1, 0), H: 2},
+		Counters:   counters,
+	}
+}
+
+type ReplayListUI struct {
+	Ui         UI
+	ButtonArea *gui.HandleArea
+}
+
+// ReplayListElements shows newest recorded games as buttons, mapped by their file path
+func ReplayListElements(app *App, records []*gameRecord) *ReplayListUI {
+	sectionText := gui.NewText(2, 1, app.T("replays.title"), app.Theme().Text)
+
+	buttonConfig := gui.NewButtonConfig()
+	buttonConfig.Height = 3
+	buttonConfig.Width = 9
+	buttonConfig.FgColor = app.Theme().ButtonText
+	buttonConfig.BgColor = app.Theme().Danger
+	returnButton := gui.NewButton(2, 3, app.T("common.return"), buttonConfig)
+
+	buttonMapping := map[string]gui.Spatial{
+		"returnButton": returnButton,
+	}
+	drawables := []gui.Drawable{
+		sectionText,
+		returnButton,
+	}
+
+	if len(records) == 0 {
+		drawables = append(drawables, gui.NewText(2, 8, app.T("replays.empty"), app.Theme().Text))
+	}
+
+	buttonConfig.BgColor = app.Theme().Primary
+	buttonConfig.Width = 50
+	for i, record := range records {
+		if i == 8 {
+			break
+		}
+		outcome := record.result()
+		if outcome == "" {
+			outcome = app.T("replays.unfinished")
+		}
+		label := app.T("replays.entry", record.Start.Time.Format("2006-01-02 15:04"), record.Start.Opponent, record.Start.Mode, outcome)
+		recordButton := gui.NewButton(2, 7+i*3, label, buttonConfig)
+		buttonMapping[record.Path] = recordButton
+		drawables = append(drawables, recordButton)
+	}
+
+	buttonArea := app.NewHandleArea(buttonMapping)
+	drawables = append(drawables, buttonArea)
+	for _, drawable := range drawables {
+		app.Draw(drawable)
+	}
+
+	return &ReplayListUI{
+		Ui:         app,
+		ButtonArea: buttonArea,
+	}
+}
+
+type ReplayUI struct {
+	Ui         UI
+	ButtonArea *gui.HandleArea
+	Text       layout.Rect // under the buttons, for the move and auto-play lines
+}
+
+// controlsText returns the part of the screen under the controls placed in the controls row
+func controlsText(controls layout.Rect, buttons []layout.Rect) layout.Rect {
+	text := layout.Content().Pad(1).Below(layout.Union(buttons...).Bottom() + 1)
+	text.W = max(text.Right()-controls.X, 0)
+	text.X = controls.X
+	return text
+}
+
+// ReplayElements draws controls of the replay viewer below the boards
+func ReplayElements(app *App) *ReplayUI {
+	controls := layout.Game().Controls
+	buttons := controls.Flow(2, layout.Repeat(layout.Box{W: 8, H: 3}, 7)...)
+
+	buttonConfig := gui.NewButtonConfig()
+	buttonConfig.Height = 3
+	buttonConfig.Width = 8
+	buttonConfig.FgColor = app.Theme().ButtonText
+	buttonConfig.BgColor = app.Theme().Primary
+	firstButton := gui.NewButton(buttons[0].X, buttons[0].Y, app.T("replay.first"), buttonConfig)
+	prevButton := gui.NewButton(buttons[1].X, buttons[1].Y, app.T("common.prev"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Success
+	playButton := gui.NewButton(buttons[2].X, buttons[2].Y, app.T("replay.play"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Primary
+	nextButton := gui.NewButton(buttons[3].X, buttons[3].Y, app.T("common.next"), buttonConfig)
+	lastButton := gui.NewButton(buttons[4].X, buttons[4].Y, app.T("replay.last"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Highlight
+	slowerButton := gui.NewButton(buttons[5].X, buttons[5].Y, app.T("replay.slower"), buttonConfig)
+	fasterButton := gui.NewButton(buttons[6].X, buttons[6].Y, app.T("replay.faster"), buttonConfig)
+
+	buttonMapping := map[string]gui.Spatial{
+		"firstButton":  firstButton,
+		"prevButton":   prevButton,
+		"playButton":   playButton,
+		"nextButton":   nextButton,
+		"lastButton":   lastButton,
+		"slowerButton": slowerButton,
+		"fasterButton": fasterButton,
+	}
+	buttonArea := app.NewHandleArea(buttonMapping)
+
+	drawables := []gui.Drawable{
+		buttonArea,
+		firstButton,
+		prevButton,
+		playButton,
+		nextButton,
+		lastButton,
+		slowerButton,
+		fasterButton,
+	}
+	for _, drawable := range drawables {
+		app.Draw(drawable)
+	}
+
+	return &ReplayUI{
+		Ui:         app,
+		ButtonArea: buttonArea,
+		Text:       controlsText(controls, buttons),
+	}
+}
+
+type HistoryUI struct {
+	Ui         UI
+	ButtonArea *gui.HandleArea
+}
+
+func HistoryElements(app *App) *HistoryUI {
+	sectionText := gui.NewText(2, 1, app.T("history.title"), app.Theme().Text)
+
+	buttonConfig := gui.NewButtonConfig()
+	buttonConfig.Height = 3
+	buttonConfig.Width = 9
+	buttonConfig.FgColor = app.Theme().ButtonText
+	buttonConfig.BgColor = app.Theme().Danger
+	returnButton := gui.NewButton(2, 3, app.T("common.return"), buttonConfig)
+
+	buttonMapping := map[string]gui.Spatial{
+		"returnButton": returnButton,
+	}
+	buttonArea := app.NewHandleArea(buttonMapping)
+
+	drawables := []gui.Drawable{
+		sectionText,
+		buttonArea,
+		returnButton,
+	}
+	for _, drawable := range drawables {
+		app.Draw(drawable)
+	}
+
+	return &HistoryUI{
+		Ui:         app,
+		ButtonArea: buttonArea,
+	}
+}
+
+type QuickMatchUI struct {
+	Ui         UI
+	ButtonArea *gui.HandleArea
+	Status     layout.Rect // the row under the buttons for the search
+}
+
+func QuickMatchElements(app *App, settings QuickMatchSettings) *QuickMatchUI {
+	area := layout.Content().Pad(2)
+	buttons := area.Below(5).Stack(1, layout.Repeat(layout.Box{W: 24, H: 3}, 5)...)
+	status := area.Below(layout.Union(buttons...).Bottom() + 1)
+	status.H = min(status.H, 1)
+
+	sectionText := gui.NewText(area.X, 1, app.T("quick.title"), app.Theme().Text)
+	helpText := gui.NewText(area.X, 2, app.T("quick.help"), app.Theme().Text)
+
+	buttonConfig := gui.NewButtonConfig()
+	buttonConfig.Height = 3
+	buttonConfig.Width = 24
+	buttonConfig.FgColor = app.Theme().ButtonText
+	buttonConfig.BgColor = app.Theme().Primary
+	waitButton := gui.NewButton(buttons[0].X, buttons[0].Y, app.T("quick.wait", settings.Wait), buttonConfig)
+	fallbackButton := gui.NewButton(buttons[1].X, buttons[1].Y, app.T("quick.fallback", settings.Fallback), buttonConfig)
+	excludeText := app.T("quick.excludeOff")
+	if settings.ExcludeRecentLosses {
+		excludeText = app.T("quick.excludeOn")
+	}
+	excludeButton := gui.NewButton(buttons[2].X, buttons[2].Y, excludeText, buttonConfig)
+	buttonConfig.BgColor = app.Theme().Success
+	buttonConfig.Width = 11
+	searchButton := gui.NewButton(buttons[3].X, buttons[3].Y, app.T("common.search"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Highlight
+	// Next to the search button, in the space left of its row
+	stopButton := gui.NewButton(buttons[3].X+13, buttons[3].Y, app.T("quick.stop"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Danger
+	returnButton := gui.NewButton(buttons[4].X, buttons[4].Y, app.T("common.return"), buttonConfig)
+
+	buttonMapping := map[string]gui.Spatial{
+		"waitButton":     waitButton,
+		"fallbackButton": fallbackButton,
+		"excludeButton":  excludeButton,
+		"searchButton":   searchButton,
+		"stopButton":     stopButton,
+		"returnButton":   returnButton,
+	}
+	buttonArea := app.NewHandleArea(buttonMapping)
+
+	drawables := []gui.Drawable{
+		sectionText,
+		helpText,
+		buttonArea,
+		waitButton,
+		fallbackButton,
+		excludeButton,
+		searchButton,
+		stopButton,
+		returnButton,
+	}
+	for _, drawable := range drawables {
+		app.Draw(drawable)
+	}
+
+	return &QuickMatchUI{
+		Ui:         app,
+		ButtonArea: buttonArea,
+		Status:     status,
+	}
+}
+
+type LeaderboardUI struct {
+	Ui          UI
+	ButtonArea  *gui.HandleArea
+	SearchField *gui.TextInput
+}
+
+// LeaderboardElements draws the controls and one page of players, a row is
+// a button named "player:" followed by the nick
+func LeaderboardElements(app *App, query leaderboardQuery, rows []PlayerStats, pages int) *LeaderboardUI {
+	sectionText := gui.NewText(2, 1, app.T("leaderboard.title"), app.Theme().Text)
+
+	buttonConfig := gui.NewButtonConfig()
+	buttonConfig.Height = 3
+	buttonConfig.Width = 9
+	buttonConfig.FgColor = app.Theme().ButtonText
+
+	// Sort buttons, the active one is highlighted
+	buttonMapping := map[string]gui.Spatial{}
+	drawables := []gui.Drawable{sectionText}
+	x := 2
+	for _, by := range leaderboardSorts {
+		buttonConfig.BgColor = app.Theme().Primary
+		if by == query.Sort {
+			buttonConfig.BgColor = app.Theme().Highlight
+		}
+		sortButton := gui.NewButton(x, 3, app.T("sort."+by), buttonConfig)
+		buttonMapping["sort:"+by] = sortButton
+		drawables = append(drawables, sortButton)
+		x += buttonConfig.Width + 1
+	}
+
+	// Search and filters
+	searchText := gui.NewText(x+2, 3, app.T("leaderboard.search", query.Search), app.Theme().Text)
+	searchField := gui.NewTextInput(x+2, 4, 16)
+	buttonConfig.BgColor = app.Theme().Success
+	searchButton := gui.NewButton(x+20, 3, app.T("common.search"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Danger
+	clearButton := gui.NewButton(x+30, 3, app.T("common.clear"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Primary
+	buttonConfig.Width = 16
+	minGamesButton := gui.NewButton(x+40, 3, app.T("leaderboard.minGames", query.MinGames), buttonConfig)
+
+	// Table
+	headerText := gui.NewText(2, 7, fmt.Sprintf("%-5s %-20s %7s %6s %6s %7s", app.T("sort.Rank"), app.T("leaderboard.nick"), app.T("sort.Points"), app.T("sort.Wins"), app.T("sort.Games"), app.T("sort.Win %")), app.Theme().Text)
+	drawables = append(drawables, searchText, searchField, searchButton, clearButton, minGamesButton, headerText)
+	rowConfig := gui.NewButtonConfig()
+	rowConfig.Height = 1
+	rowConfig.Width = 60
+	rowConfig.FgColor = app.Theme().Foreground
+	rowConfig.BgColor = app.Theme().Background
+	nick := app.Profile().Nick
+	for i, player := range rows {
+		rowConfig.FgColor = app.Theme().Foreground
+		if player.Nick == nick {
+			rowConfig.FgColor = app.Theme().Highlight
+		}
+		row := fmt.Sprintf("%-5d %-20.20s %7d %6d %6d %6.1f%%", player.Rank, player.Nick, player.Points, player.Wins, player.Games, player.WinRate())
+		rowButton := gui.NewButton(2, 8+i, row, rowConfig)
+		buttonMapping["player:"+player.Nick] = rowButton
+		drawables = append(drawables, rowButton)
+	}
+	if len(rows) == 0 {
+		drawables = append(drawables, gui.NewText(2, 8, app.T("leaderboard.empty"), app.Theme().Text))
+	}
+
+	// Paging
+	y := 9 + leaderboardPageSize
+	pageText := gui.NewText(2, y, app.T("leaderboard.page", query.Page+1, max(pages, 1)), app.Theme().Text)
+	buttonConfig.Width = 9
+	buttonConfig.BgColor = app.Theme().Success
+	prevButton := gui.NewButton(2, y+1, app.T("common.prev"), buttonConfig)
+	nextButton := gui.NewButton(12, y+1, app.T("common.next"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Highlight
+	meButton := gui.NewButton(22, y+1, app.T("leaderboard.me"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Danger
+	returnButton := gui.NewButton(32, y+1, app.T("common.return"), buttonConfig)
+	drawables = append(drawables, pageText, prevButton, nextButton, meButton, returnButton)
+
+	buttonMapping["searchButton"] = searchButton
+	buttonMapping["clearButton"] = clearButton
+	buttonMapping["minGamesButton"] = minGamesButton
+	buttonMapping["prevButton"] = prevButton
+	buttonMapping["nextButton"] = nextButton
+	buttonMapping["meButton"] = meButton
+	buttonMapping["returnButton"] = returnButton
+	buttonArea := app.NewHandleArea(buttonMapping)
+	drawables = append(drawables, buttonArea)
+
+	for _, drawable := range drawables {
+		app.Draw(drawable)
+	}
+
+	return &LeaderboardUI{
+		Ui:          app,
+		ButtonArea:  buttonArea,
+		SearchField: searchField,
+	}
+}
+
+type PlayerDetailUI struct {
+	Ui         UI
+	ButtonArea *gui.HandleArea
+}
+
+func PlayerDetailElements(app *App, nick string) *PlayerDetailUI {
+	sectionText := gui.NewText(2, 1, app.T("player.title", nick), app.Theme().Text)
+
+	buttonConfig := gui.NewButtonConfig()
+	buttonConfig.Height = 3
+	buttonConfig.Width = 9
+	buttonConfig.FgColor = app.Theme().ButtonText
+	buttonConfig.BgColor = app.Theme().Danger
+	returnButton := gui.NewButton(2, 3, app.T("common.return"), buttonConfig)
+
+	buttonMapping := map[string]gui.Spatial{
+		"returnButton": returnButton,
+	}
+	buttonArea := app.NewHandleArea(buttonMapping)
+
+	drawables := []gui.Drawable{
+		sectionText,
+		buttonArea,
+		returnButton,
+	}
+	for _, drawable := range drawables {
+		app.Draw(drawable)
+	}
+
+	return &PlayerDetailUI{
+		Ui:         app,
+		ButtonArea: buttonArea,
+	}
+}
+
+type CompareUI struct {
+	Ui         UI
+	ButtonArea *gui.HandleArea
+}
+
+func CompareElements(app *App, opponent string, canChallenge bool) *CompareUI {
+	sectionText := gui.NewText(2, 1, app.T("compare.title", opponent), app.Theme().Text)
+
+	buttonConfig := gui.NewButtonConfig()
+	buttonConfig.Height = 3
+	buttonConfig.Width = 11
+	buttonConfig.FgColor = app.Theme().ButtonText
+	buttonConfig.BgColor = app.Theme().Danger
+	returnButton := gui.NewButton(2, 3, app.T("common.return"), buttonConfig)
+
+	buttonMapping := map[string]gui.Spatial{
+		"returnButton": returnButton,
+	}
+	drawables := []gui.Drawable{
+		sectionText,
+		returnButton,
+	}
+	if canChallenge {
+		buttonConfig.BgColor = app.Theme().Success
+		challengeButton := gui.NewButton(15, 3, app.T("compare.challenge"), buttonConfig)
+		buttonMapping["challengeButton"] = challengeButton
+		drawables = append(drawables, challengeButton)
+	}
+	buttonArea := app.NewHandleArea(buttonMapping)
+	drawables = append(drawables, buttonArea)
+
+	for _, drawable := range drawables {
+		app.Draw(drawable)
+	}
+
+	return &CompareUI{
+		Ui:         app,
+		ButtonArea: buttonArea,
+	}
+}
+
+type SpectatorUI struct {
+	Ui         UI
+	ButtonArea *gui.HandleArea
+	Drawable   []gui.Drawable
+	Text       layout.Rect // under the buttons, for the games line
+}
+
+// SpectatorElements draws controls of the spectator screen, they're removed and drawn
+// again when a strategy changes
+func SpectatorElements(app *App, names [2]string) *SpectatorUI {
+	controls := layout.Game().Controls
+	buttons := controls.Flow(2,
+		layout.Box{W: 8, H: 3}, layout.Box{W: 8, H: 3}, layout.Box{W: 8, H: 3},
+		layout.Box{W: 10, H: 3}, layout.Box{W: 12, H: 3}, layout.Box{W: 12, H: 3})
+
+	buttonConfig := gui.NewButtonConfig()
+	buttonConfig.Height = 3
+	buttonConfig.Width = 8
+	buttonConfig.FgColor = app.Theme().ButtonText
+	buttonConfig.BgColor = app.Theme().Success
+	playButton := gui.NewButton(buttons[0].X, buttons[0].Y, app.T("spectator.pause"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Highlight
+	slowerButton := gui.NewButton(buttons[1].X, buttons[1].Y, app.T("replay.slower"), buttonConfig)
+	fasterButton := gui.NewButton(buttons[2].X, buttons[2].Y, app.T("replay.faster"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Primary
+	buttonConfig.Width = 10
+	newGameButton := gui.NewButton(buttons[3].X, buttons[3].Y, app.T("spectator.newGame"), buttonConfig)
+	buttonConfig.Width = 12
+	strategyAButton := gui.NewButton(buttons[4].X, buttons[4].Y, app.T("spectator.strategy", 1, names[0]), buttonConfig)
+	strategyBButton := gui.NewButton(buttons[5].X, buttons[5].Y, app.T("spectator.strategy", 2, names[1]), buttonConfig)
+
+	buttonMapping := map[string]gui.Spatial{
+		"playButton":      playButton,
+		"slowerButton":    slowerButton,
+		"fasterButton":    fasterButton,
+		"newGameButton":   newGameButton,
+		"strategyAButton": strategyAButton,
+		"strategyBButton": strategyBButton,
+	}
+	buttonArea := app.NewHandleArea(buttonMapping)
+
+	drawables := []gui.Drawable{
+		buttonArea,
+		playButton,
+		slowerButton,
+		fasterButton,
+		newGameButton,
+		strategyAButton,
+		strategyBButton,
+	}
+	for _, drawable := range drawables {
+		app.Draw(drawable)
+	}
+
+	return &SpectatorUI{
+		Ui:         app,
+		ButtonArea: buttonArea,
+		Drawable:   drawables,
+		Text:       controlsText(controls, buttons),
+	}
+}
+
+type GameSettingsUI struct {
+	Ui         UI
+	ButtonArea *gui.HandleArea
+}
+
+func GameSettingsElements(app *App, settings GameSettings) *GameSettingsUI {
+	area := layout.Content().Pad(2)
+	buttons := area.Below(4).Stack(1, layout.Repeat(layout.Box{W: 30, H: 3}, 8)...)
+
+	sectionText := gui.NewText(area.X, 1, app.T("settings.title"), app.Theme().Text)
+	helpText := gui.NewText(area.X, 2, app.T("settings.help"), app.Theme().Text)
+
+	warnings := app.T("settings.none")
+	if len(settings.Warnings) > 0 {
+		warnings = strings.Trim(fmt.Sprint(settings.Warnings), "[]")
+	}
+
+	buttonConfig := gui.NewButtonConfig()
+	buttonConfig.Height = 3
+	buttonConfig.Width = 30
+	buttonConfig.FgColor = app.Theme().ButtonText
+	buttonConfig.BgColor = app.Theme().Primary
+	warningsButton := gui.NewButton(buttons[0].X, buttons[0].Y, app.T("settings.warnings", warnings), buttonConfig)
+	bellButton := gui.NewButton(buttons[1].X, buttons[1].Y, app.T("settings.bell", onOff(app, settings.Bell)), buttonConfig)
+	autoFireButton := gui.NewButton(buttons[2].X, buttons[2].Y, app.T("settings.autoFire", onOff(app, settings.AutoFire)), buttonConfig)
+	autoFireAtButton := gui.NewButton(buttons[3].X, buttons[3].Y, app.T("settings.autoFireAt", settings.AutoFireAt), buttonConfig)
+	strategyButton := gui.NewButton(buttons[4].X, buttons[4].Y, app.T("settings.strategy", settings.Strategy), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Highlight
+	rankedButton := gui.NewButton(buttons[5].X, buttons[5].Y, app.T("settings.ranked", onOff(app, settings.Ranked)), buttonConfig)
+	heatmapButton := gui.NewButton(buttons[6].X, buttons[6].Y, app.T("settings.heatmap", onOff(app, settings.Heatmap)), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Danger
+	returnButton := gui.NewButton(buttons[7].X, buttons[7].Y, app.T("common.return"), buttonConfig)
+
+	buttonMapping := map[string]gui.Spatial{
+		"warningsButton":   warningsButton,
+		"bellButton":       bellButton,
+		"autoFireButton":   autoFireButton,
+		"autoFireAtButton": autoFireAtButton,
+		"strategyButton":   strategyButton,
+		"rankedButton":     rankedButton,
+		"heatmapButton":    heatmapButton,
+		"returnButton":     returnButton,
+	}
+	buttonArea := app.NewHandleArea(buttonMapping)
+
+	drawables := []gui.Drawable{
+		sectionText,
+		helpText,
+		buttonArea,
+		warningsButton,
+		bellButton,
+		autoFireButton,
+		autoFireAtButton,
+		strategyButton,
+		rankedButton,
+		heatmapButton,
+		returnButton,
+	}
+	for _, drawable := range drawables {
+		app.Draw(drawable)
+	}
+
+	return &GameSettingsUI{
+		Ui:         app,
+		ButtonArea: buttonArea,
+	}
+}
+
+type HintUI struct {
+	Ui         UI
+	ButtonArea *gui.HandleArea
+	Heatmap    *gui.Board // drawn over the opponent board, nil if the heatmap is off
+	Marker     *gui.Board // drawn over the opponent board with the hint if the heatmap is off
+}
+
+func HintElements(app *App, heatmap bool) *HintUI {
+	// Next to the exit button at the end of the controls row
+	g := layout.Game()
+	hintRect := g.Hint
+
+	buttonConfig := gui.NewButtonConfig()
+	buttonConfig.Width = hintRect.W
+	buttonConfig.FgColor = app.Theme().ButtonText
+	buttonConfig.BgColor = app.Theme().Highlight
+	hintButton := gui.NewButton(hintRect.X, hintRect.Y, app.T("game.hintButton"), buttonConfig)
+
+	buttonArea := app.NewHandleArea(map[string]gui.Spatial{
+		"hintButton": hintButton,
+	})
+	app.Draw(buttonArea)
+	app.Draw(hintButton)
+
+	hintUi := &HintUI{
+		Ui:         app,
+		ButtonArea: buttonArea,
+	}
+	if heatmap {
+		hintUi.Heatmap = gui.NewBoard(g.Opponent.X, g.Opponent.Y, app.Theme().HeatmapConfig())
+	} else {
+		hintUi.Marker = gui.NewBoard(g.Opponent.X, g.Opponent.Y, app.Theme().HintConfig())
+	}
+	return hintUi
+}
+
+type ResumeUI struct {
+	Ui         UI
+	ButtonArea *gui.HandleArea
+}
+
+func ResumeElements(app *App, session *gameSession) *ResumeUI {
+	area := layout.Content().Pad(2)
+	buttons := area.Below(5).Stack(1, layout.Repeat(layout.Box{W: 20, H: 3}, 2)...)
+
+	titleText := gui.NewText(area.X, 1, app.T("resume.title", session.Opponent, len(session.Shots)), app.Theme().HighlightText)
+	helpText := gui.NewText(area.X, 3, app.T("resume.help"), app.Theme().Text)
+
+	buttonConfig := gui.NewButtonConfig()
+	buttonConfig.Height = 3
+	buttonConfig.Width = 20
+	buttonConfig.FgColor = app.Theme().ButtonText
+	buttonConfig.BgColor = app.Theme().Success
+	resumeButton := gui.NewButton(buttons[0].X, buttons[0].Y, app.T("resume.resume"), buttonConfig)
+	buttonConfig.BgColor = app.Theme().Danger
+	abandonButton := gui.NewButton(buttons[1].X, buttons[1].Y, app.T("resume.abandon"), buttonConfig)
+
+	buttonMapping := map[string]gui.Spatial{
+		"resumeButton":  resumeButton,
+		"abandonButton": abandonButton,
+	}
+	buttonArea := app.NewHandleArea(buttonMapping)
+
+	drawables := []gui.Drawable{
+		titleText,
+		helpText,
+		buttonArea,
+		resumeButton,
+		abandonButton,
+	}
+	for _, drawable := range drawables {
+		app.Draw(drawable)
+	}
+
+	return &ResumeUI{
+		Ui:         app,
 		ButtonArea: buttonArea,
 	}
 }
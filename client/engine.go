@@ -0,0 +1,139 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+)
+
+// LocalGame is an offline game between two fleets that follows the server rules,
+// a player keeps shooting after a hit. It's used to simulate bot games.
+type LocalGame struct {
+	ships [2]map[int]Ship
+	hits  [2]map[string]bool // cells of player i hit by the other player
+	shots [2]int
+	turn  int
+}
+
+func NewLocalGame(first, second []string) *LocalGame {
+	return &LocalGame{
+		ships: [2]map[int]Ship{mapShips(first), mapShips(second)},
+		hits:  [2]map[string]bool{{}, {}},
+	}
+}
+
+// Turn returns which player should fire now
+func (g *LocalGame) Turn() int {
+	return g.turn
+}
+
+func (g *LocalGame) Shots(player int) int {
+	return g.shots[player]
+}
+
+// Fire shoots at the fleet of the other player and returns hit, miss or sunk
+func (g *LocalGame) Fire(shooter int, coord string) (string, error) {
+	if shooter != g.turn {
+		return "", errors.New("not your turn")
+	}
+	if _, _, err := coordToIndex(coord); err != nil {
+		return "", err
+	}
+	target := 1 - shooter
+	g.shots[shooter]++
+
+	for _, ship := range g.ships[target] {
+		if findIndex(ship.Coords, coord) == -1 {
+			continue
+		}
+		g.hits[target][coord] = true
+		for _, shipCoord := range ship.Coords {
+			if !g.hits[target][shipCoord] {
+				return "hit", nil
+			}
+		}
+		return "sunk", nil
+	}
+
+	g.turn = target
+	return "miss", nil
+}
+
+// Winner returns the player that sunk the whole enemy fleet or -1 if the game is still on
+func (g *LocalGame) Winner() int {
+	for player := 0; player < 2; player++ {
+		target := 1 - player
+		allSunk := true
+		for _, ship := range g.ships[target] {
+			for _, coord := range ship.Coords {
+				if !g.hits[target][coord] {
+					allSunk = false
+				}
+			}
+		}
+		if allSunk {
+			return player
+		}
+	}
+	return -1
+}
+
+// SimulationResult sums up a series of local bot games
+type SimulationResult struct {
+	Strategies   [2]string `json:"strategies"`
+	Games        int       `json:"games"`
+	Wins         [2]int    `json:"wins"`
+	ShotsToWin   [2]int    `json:"shots_to_win"` // total shots fired in won games
+	AbandonedBy  [2]int    `json:"abandoned_by"`
+	ShotsPerGame float64   `json:"shots_per_game"`
+}
+
+func (r SimulationResult) AvgShotsToWin(player int) float64 {
+	if r.Wins[player] == 0 {
+		return 0
+	}
+	return float64(r.ShotsToWin[player]) / float64(r.Wins[player])
+}
+
+// Simulate plays games between two strategies on random layouts, players take turns starting
+func Simulate(first, second string, games int) (SimulationResult, error) {
+	result := SimulationResult{Strategies: [2]string{first, second}, Games: games}
+	totalShots := 0
+
+	for i := 0; i < games; i++ {
+		var strategies [2]Strategy
+		for player, name := range result.Strategies {
+			strategy, err := NewStrategy(name)
+			if err != nil {
+				return result, err
+			}
+			strategies[player] = strategy
+		}
+
+		game := NewLocalGame(RandomLayout(), RandomLayout())
+		game.turn = i % 2
+		winner := -1
+		for winner == -1 {
+			shooter := game.Turn()
+			coord := strategies[shooter].NextShot()
+			if coord == "" {
+				result.AbandonedBy[shooter]++
+				winner = 1 - shooter
+				break
+			}
+			shot, err := game.Fire(shooter, coord)
+			if err != nil {
+				return result, fmt.Errorf("game %d: %w", i+1, err)
+			}
+			strategies[shooter].Record(coord, shot)
+			winner = game.Winner()
+		}
+
+		result.Wins[winner]++
+		result.ShotsToWin[winner] += game.Shots(winner)
+		totalShots += game.Shots(0) + game.Shots(1)
+	}
+	if games > 0 {
+		result.ShotsPerGame = float64(totalShots) / float64(games)
+	}
+	return result, nil
+}
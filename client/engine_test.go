@@ -0,0 +1,27 @@
+package client
+
+import "testing"
+
+func TestSimulate(t *testing.T) {
+	result, err := Simulate("hunt", "random", 20)
+	if err != nil {
+		t.Fatalf("Simulate() error = %v", err)
+	}
+	if got := result.Wins[0] + result.Wins[1]; got != 20 {
+		t.Errorf("%d games won, want 20", got)
+	}
+	if result.AbandonedBy != [2]int{} {
+		t.Errorf("games abandoned %v, strategies should always find a cell to shoot", result.AbandonedBy)
+	}
+	// Every game needs at least the 20 ship cells hit by the winner
+	if result.ShotsPerGame < 20 || result.ShotsPerGame > 200 {
+		t.Errorf("%.1f shots per game, want between 20 and 200", result.ShotsPerGame)
+	}
+	if result.Wins[0] <= result.Wins[1] {
+		t.Errorf("hunt won %d games against random's %d", result.Wins[0], result.Wins[1])
+	}
+
+	if _, err := Simulate("hunt", "nope", 1); err == nil {
+		t.Error("Simulate() with an unknown strategy succeeded")
+	}
+}
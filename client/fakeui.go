@@ -0,0 +1,202 @@
+package client
+
+import (
+	"context"
+	"sync"
+
+	gui "github.com/s25867/warships-gui/v2"
+)
+
+// FakeUI is an in-memory UI for tests. It records everything that was drawn
+// and answers listens with clicks and inputs scripted with Click, ClickCell and Type.
+type FakeUI struct {
+	mu      sync.Mutex
+	current string
+	drawn   map[string][]gui.Drawable // drawables of each screen
+	screens []string
+	states  map[*gui.Board][10][10]gui.State
+	areas   map[*gui.HandleArea]*fakeArea
+	inputs  []string
+	bells   int
+
+	cells chan string
+}
+
+// fakeArea is a handle area with the names of its buttons and clicks waiting for its listener
+type fakeArea struct {
+	names  map[string]bool
+	clicks chan string
+}
+
+func NewFakeUI() *FakeUI {
+	return &FakeUI{
+		drawn:  make(map[string][]gui.Drawable),
+		states: make(map[*gui.Board][10][10]gui.State),
+		areas:  make(map[*gui.HandleArea]*fakeArea),
+		cells:  make(chan string, 100),
+	}
+}
+
+// Click queues a click on the button with the given name for the area that owns it.
+// The area drawn last on the current screen gets the click, as it's drawn on top.
+// Clicks on buttons no live area owns are dropped and Click returns false.
+func (f *FakeUI) Click(name string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	drawn := f.drawn[f.current]
+	for i := len(drawn) - 1; i >= 0; i-- {
+		handleArea, ok := drawn[i].(*gui.HandleArea)
+		if !ok {
+			continue
+		}
+		area := f.areas[handleArea]
+		if area == nil || !area.names[name] {
+			continue
+		}
+		select {
+		case area.clicks <- name:
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// ClickCell queues a click on a board cell, it's returned by the next ListenBoard
+func (f *FakeUI) ClickCell(coord string) {
+	f.cells <- coord
+}
+
+// Type queues text returned by the next ReadInput
+func (f *FakeUI) Type(text string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.inputs = append(f.inputs, text)
+}
+
+// Drawn returns everything drawn on the current screen
+func (f *FakeUI) Drawn() []gui.Drawable {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	drawn := make([]gui.Drawable, len(f.drawn[f.current]))
+	copy(drawn, f.drawn[f.current])
+	return drawn
+}
+
+// Screen returns the screen that is currently shown
+func (f *FakeUI) Screen() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.screens) == 0 {
+		return ""
+	}
+	return f.screens[len(f.screens)-1]
+}
+
+// Bells returns how many times the bell rang
+func (f *FakeUI) Bells() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.bells
+}
+
+// States returns the last states set on the board
+func (f *FakeUI) States(b *gui.Board) [10][10]gui.State {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.states[b]
+}
+
+func (f *FakeUI) Draw(d gui.Drawable) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.drawn[f.current] = append(f.drawn[f.current], d)
+}
+
+func (f *FakeUI) Remove(d gui.Drawable) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	drawn := f.drawn[f.current]
+	for i := range drawn {
+		if drawn[i] == d {
+			f.drawn[f.current] = append(drawn[:i:i], drawn[i+1:]...)
+			break
+		}
+	}
+}
+
+// NewScreen starts the screen empty, areas drawn on it before are gone
+func (f *FakeUI) NewScreen(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.drawn[name] = nil
+}
+
+func (f *FakeUI) SetScreen(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.current = name
+	f.screens = append(f.screens, name)
+}
+
+func (f *FakeUI) SetStates(b *gui.Board, states [10][10]gui.State) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.states[b] = states
+}
+
+func (f *FakeUI) NewHandleArea(buttons map[string]gui.Spatial) *gui.HandleArea {
+	handleArea := gui.NewHandleArea(buttons)
+	area := &fakeArea{names: make(map[string]bool, len(buttons)), clicks: make(chan string, 100)}
+	for name := range buttons {
+		area.names[name] = true
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.areas[handleArea] = area
+	return handleArea
+}
+
+func (f *FakeUI) ListenArea(ctx context.Context, handleArea *gui.HandleArea) string {
+	f.mu.Lock()
+	area := f.areas[handleArea]
+	f.mu.Unlock()
+	if area == nil {
+		// An area FakeUI didn't create can't be clicked
+		<-ctx.Done()
+		return ""
+	}
+	select {
+	case clicked := <-area.clicks:
+		return clicked
+	case <-ctx.Done():
+		return ""
+	}
+}
+
+func (f *FakeUI) ListenBoard(ctx context.Context, b *gui.Board) string {
+	select {
+	case coord := <-f.cells:
+		return coord
+	case <-ctx.Done():
+		return ""
+	}
+}
+
+func (f *FakeUI) ReadInput(input *gui.TextInput) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.inputs) == 0 {
+		return ""
+	}
+	text := f.inputs[0]
+	f.inputs = f.inputs[1:]
+	return text
+}
+
+func (f *FakeUI) Bell() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.bells++
+}
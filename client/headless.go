@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Functions for playing without the GUI, used by the command line interface
+
+// How many failed requests in a row are tolerated before giving up
+const headlessMaxErrors = 20
+
+// fetchGameStatus gets and parses the game status, retrying failed requests
+func (c *APIClient) fetchGameStatus(ctx context.Context, playerToken string) (GameStatusResponse, error) {
+	var gameStatus GameStatusResponse
+	var err error
+	for i := 0; i < headlessMaxErrors; i++ {
+		var response string
+		response, err = c.GetGameStatus(playerToken)
+		if err == nil {
+			if err = json.Unmarshal([]byte(response), &gameStatus); err == nil {
+				return gameStatus, nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return gameStatus, ctx.Err()
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+	return gameStatus, fmt.Errorf("error getting game status: %w", err)
+}
+
+// WaitForGame blocks until the game of the given player starts. While waiting
+// in the lobby the lobby timer is refreshed so the player isn't kicked out.
+func (c *APIClient) WaitForGame(ctx context.Context, playerToken string) error {
+	lastRefresh := time.Now()
+	for {
+		gameStatus, err := c.fetchGameStatus(ctx, playerToken)
+		if err != nil {
+			return err
+		}
+		switch gameStatus.GameStatus {
+		case "game_in_progress":
+			return nil
+		case "ended":
+			return errors.New("game ended before it started")
+		case "":
+			return errors.New("player is not in the lobby anymore")
+		}
+
+		if time.Since(lastRefresh) > 10*time.Second {
+			if err := c.RefreshLobby(playerToken); err == nil {
+				lastRefresh = time.Now()
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// FireWithResult fires at the given coordinate and returns the result of the shot
+func (c *APIClient) FireWithResult(playerToken, coord string) (string, error) {
+	response, err := c.FireAtEnemy(playerToken, coord)
+	if err != nil {
+		return "", err
+	}
+	var fireMap map[string]interface{}
+	if err := json.Unmarshal([]byte(response), &fireMap); err != nil {
+		return "", fmt.Errorf("error parsing fire response: %w", err)
+	}
+	result, ok := fireMap["result"].(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected fire response: %s", response)
+	}
+	return result, nil
+}
+
+// RunBot plays a started game using the strategy until it ends and returns the outcome
+func (c *APIClient) RunBot(ctx context.Context, playerToken string, strategy Strategy, logf func(format string, args ...any)) (string, error) {
+	for {
+		gameStatus, err := c.fetchGameStatus(ctx, playerToken)
+		if err != nil {
+			return "", err
+		}
+		if gameStatus.GameStatus == "ended" {
+			return gameStatus.LastGameStatus, nil
+		}
+
+		if gameStatus.ShouldFire {
+			coord := strategy.NextShot()
+			if coord == "" {
+				logf("no coordinates left to fire at, abandoning game")
+				_, err := c.AbandonGame(playerToken)
+				return "lose", err
+			}
+
+			result, err := c.FireWithResult(playerToken, coord)
+			if err != nil {
+				logf("error firing at %s: %v", coord, err)
+			} else {
+				strategy.Record(coord, result)
+				logf("fired at %s: %s", coord, result)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
-	"sync"
 )
 
 type Ship struct {
@@ -13,12 +12,7 @@ type Ship struct {
 	IsDestroyed     string
 }
 
-var shipsMutex sync.Mutex
-
 func mapShips(coords []string) map[int]Ship {
-	shipsMutex.Lock()
-	defer shipsMutex.Unlock()
-
 	ships := make(map[int]Ship)
 	shipIndex := 0
 
@@ -203,3 +197,19 @@ func abs(x int) int {
 	}
 	return x
 }
+
+// coordToIndex converts a coordinate like "B7" into board indexes (col 1, row 6)
+func coordToIndex(coord string) (col, row int, err error) {
+	if len(coord) < 2 || coord[0] < 'A' || coord[0] > 'J' {
+		return 0, 0, fmt.Errorf("invalid coordinate: %q", coord)
+	}
+	row, err = strconv.Atoi(coord[1:])
+	if err != nil || row < 1 || row > 10 {
+		return 0, 0, fmt.Errorf("invalid coordinate: %q", coord)
+	}
+	return int(coord[0] - 'A'), row - 1, nil
+}
+
+func indexToCoord(col, row int) string {
+	return fmt.Sprintf("%c%d", 'A'+col, row+1)
+}
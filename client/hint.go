@@ -0,0 +1,143 @@
+package client
+
+import (
+	"slices"
+	"sync"
+
+	gui "github.com/s25867/warships-gui/v2"
+)
+
+// Weight of ship placements going through hits that don't belong to a sunk ship yet,
+// so that the heatmap shows cells next to hit ships as the hottest
+const hitPlacementWeight = 20
+
+// hintHeatmap counts for every unknown cell the placements of ships still afloat
+// that go through it, placements through unfinished hits weigh more. Ships may be
+// bent as in any layout the server accepts, so every shape of each size is counted.
+func hintHeatmap(grid *targetGrid) [10][10]int {
+	var heat [10][10]int
+	for _, size := range grid.afloatSizes() {
+		for _, shape := range shipShapes(size) {
+			for col := 0; col <= 9; col++ {
+				for row := 0; row <= 9; row++ {
+					ship := placedShip{Col: col, Row: row, Shape: shape}
+					fits, hits := true, 0
+					for _, cell := range ship.cells() {
+						if cell[0] > 9 || cell[1] > 9 {
+							fits = false
+							break
+						}
+						switch grid[cell[0]][cell[1]] {
+						case cellHit:
+							hits++
+						case cellMiss, cellSunk, cellInferredEmpty:
+							fits = false
+						}
+					}
+					if !fits {
+						continue
+					}
+					for _, cell := range ship.cells() {
+						if grid[cell[0]][cell[1]] == cellUnknown {
+							heat[cell[0]][cell[1]] += 1 + hits*hitPlacementWeight
+						}
+					}
+				}
+			}
+		}
+	}
+	return heat
+}
+
+// heatStates turns the heatmap into board states for HeatmapConfig
+func heatStates(heat [10][10]int, hint string) [10][10]gui.State {
+	best := 0
+	for _, column := range heat {
+		best = max(best, slices.Max(column[:]))
+	}
+	var states [10][10]gui.State
+	for col := 0; col < 10; col++ {
+		for row := 0; row < 10; row++ {
+			switch {
+			case heat[col][row] == 0:
+				states[col][row] = gui.Empty
+			case heat[col][row]*3 < best:
+				states[col][row] = gui.Miss
+			case heat[col][row]*3 < best*2:
+				states[col][row] = gui.Ship
+			default:
+				states[col][row] = gui.Hit
+			}
+		}
+	}
+	if col, row, err := coordToIndex(hint); err == nil {
+		states[col][row] = gui.Sunk
+	}
+	return states
+}
+
+// hintOverlay follows our shots and on request highlights the cell the strategy picked
+// in the game settings recommends, on a board drawn over the opponent board
+type hintOverlay struct {
+	app      *App
+	strategy Strategy // the same kind of strategy auto-fire uses
+
+	mu      sync.Mutex
+	grid    targetGrid
+	heatmap *gui.Board // nil without the heatmap
+	marker  *gui.Board // shows only the hint, used without the heatmap
+	shown   *gui.Board // the board drawn with the hint, nil if it's hidden
+}
+
+func newHintOverlay(app *App, strategy Strategy) *hintOverlay {
+	return &hintOverlay{app: app, strategy: strategy}
+}
+
+// record passes the result of our shot to the strategy
+func (h *hintOverlay) record(coord, result string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.strategy.Record(coord, result)
+}
+
+// update takes what we know after our shot and hides the hint
+func (h *hintOverlay) update(known targetGrid) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.grid = known
+	h.hide()
+}
+
+// show highlights the recommended cell and returns it, an empty string if there is nothing to shoot at
+func (h *hintOverlay) show() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hide()
+	hint := autoFireShot(h.strategy, &h.grid)
+	if hint == "" {
+		return ""
+	}
+	overlay, states := h.heatmap, [10][10]gui.State{}
+	if overlay != nil {
+		states = heatStates(hintHeatmap(&h.grid), hint)
+	} else {
+		// Ships are never shown on the opponent board, so the hint is the only one
+		overlay, states = h.marker, h.grid.states()
+		col, row, _ := coordToIndex(hint)
+		states[col][row] = gui.Ship
+	}
+	if overlay != nil {
+		h.app.SetStates(overlay, states)
+		h.app.Draw(overlay)
+		h.shown = overlay
+	}
+	return hint
+}
+
+// hide removes the hint, the caller holds the lock
+func (h *hintOverlay) hide() {
+	if h.shown != nil {
+		h.app.Remove(h.shown)
+		h.shown = nil
+	}
+}
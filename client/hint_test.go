@@ -0,0 +1,74 @@
+package client
+
+import "testing"
+
+func TestHintHeatmap(t *testing.T) {
+	t.Run("empty board is hottest in the middle", func(t *testing.T) {
+		var grid targetGrid
+		heat := hintHeatmap(&grid)
+		if heat[0][0] >= heat[4][4] {
+			t.Errorf("corner heat %d, middle heat %d, want the middle hotter", heat[0][0], heat[4][4])
+		}
+	})
+
+	t.Run("known cells have no heat", func(t *testing.T) {
+		var grid targetGrid
+		grid.record("B2", "miss")
+		grid.record("E5", "sunk")
+		heat := hintHeatmap(&grid)
+		for _, coord := range []string{"B2", "E5", "D4", "F6"} {
+			col, row, _ := coordToIndex(coord)
+			if heat[col][row] != 0 {
+				t.Errorf("heat of %s = %d, want 0", coord, heat[col][row])
+			}
+		}
+	})
+
+	t.Run("cells next to a hit are hottest", func(t *testing.T) {
+		var grid targetGrid
+		grid.record("E5", "hit")
+		heat := hintHeatmap(&grid)
+		best := 0
+		for col := range heat {
+			for row := range heat[col] {
+				best = max(best, heat[col][row])
+			}
+		}
+		for _, coord := range []string{"D5", "F5", "E4", "E6"} {
+			col, row, _ := coordToIndex(coord)
+			if heat[col][row]*2 < best {
+				t.Errorf("heat of %s next to the hit = %d, best %d", coord, heat[col][row], best)
+			}
+		}
+		if heat[0][9] >= heat[3][4] {
+			t.Errorf("far cell heat %d, cell next to the hit %d", heat[0][9], heat[3][4])
+		}
+	})
+
+	t.Run("bent ships through a hit reach the diagonal", func(t *testing.T) {
+		var empty, grid targetGrid
+		grid.record("E5", "hit")
+		col, row, _ := coordToIndex("D4")
+		before, after := hintHeatmap(&empty)[col][row], hintHeatmap(&grid)[col][row]
+		if after-before < 2*hitPlacementWeight {
+			t.Errorf("heat of D4 = %d with a hit on E5, %d without, want bent placements through the hit counted", after, before)
+		}
+	})
+}
+
+func TestHintOverlayShowsUnknownCell(t *testing.T) {
+	ui := NewFakeUI()
+	app := NewApp(ui, nil, Profile{Nick: "tester"}, "test", nil)
+	strategy, _ := NewStrategy("hunt")
+	hints := newHintOverlay(app, strategy)
+
+	var known targetGrid
+	for _, shot := range [][2]string{{"E5", "hit"}, {"E4", "miss"}, {"D5", "miss"}, {"F5", "miss"}} {
+		known.record(shot[0], shot[1])
+		hints.record(shot[0], shot[1])
+	}
+	hints.update(known)
+	if got := hints.show(); got != "E6" {
+		t.Errorf("show() = %q, want E6, the only cell left next to the hit", got)
+	}
+}
@@ -0,0 +1,267 @@
+package client
+
+import (
+	"BomboweStatki/layout"
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"time"
+
+	gui "github.com/s25867/warships-gui/v2"
+)
+
+// matchSummary is a single game from the local history
+type matchSummary struct {
+	Time     time.Time
+	Opponent string
+	Mode     string
+	Outcome  string // win, lose or empty if the game wasn't finished
+	Shots    int
+	Hits     int
+	Duration time.Duration
+	Layout   string
+}
+
+type winRate struct {
+	Games int
+	Wins  int
+}
+
+func (w *winRate) add(won bool) {
+	w.Games++
+	if won {
+		w.Wins++
+	}
+}
+
+func (w winRate) String() string {
+	if w.Games == 0 {
+		return "N/A"
+	}
+	return fmt.Sprintf("%d/%d (%.0f%%)", w.Wins, w.Games, float64(w.Wins)/float64(w.Games)*100)
+}
+
+// playerAnalytics are trends computed from all locally recorded games
+type playerAnalytics struct {
+	Matches       []matchSummary
+	Total         winRate
+	ByOpponent    map[string]*winRate
+	ByLayout      map[string]*winRate
+	ByMode        map[string]*winRate
+	AvgShotsToWin float64
+	HitHeatmap    [10][10]int // how many times opponents hit each of our cells
+}
+
+func summarizeGame(record *gameRecord) matchSummary {
+	summary := matchSummary{
+		Time:     record.Start.Time,
+		Opponent: record.Start.Opponent,
+		Mode:     record.Start.Mode,
+		Outcome:  record.End.Outcome,
+		Layout:   layoutName(record.Start.Coords),
+	}
+	for _, shot := range record.Shots {
+		if shot.Shooter == "player" {
+			summary.Shots++
+			if shot.Result == "hit" || shot.Result == "sunk" {
+				summary.Hits++
+			}
+		}
+	}
+	if !record.End.Time.IsZero() {
+		summary.Duration = record.End.Time.Sub(record.Start.Time)
+	} else if len(record.Shots) > 0 {
+		summary.Duration = record.Shots[len(record.Shots)-1].Time.Sub(record.Start.Time)
+	}
+	return summary
+}
+
+func computeAnalytics(records []*gameRecord) playerAnalytics {
+	analytics := playerAnalytics{
+		ByOpponent: make(map[string]*winRate),
+		ByLayout:   make(map[string]*winRate),
+		ByMode:     make(map[string]*winRate),
+	}
+
+	shotsToWin := 0
+	for _, record := range records {
+		summary := summarizeGame(record)
+		analytics.Matches = append(analytics.Matches, summary)
+
+		for _, shot := range record.Shots {
+			if shot.Shooter == "opponent" && (shot.Result == "hit" || shot.Result == "sunk") {
+				if col, row, err := coordToIndex(shot.Coord); err == nil {
+					analytics.HitHeatmap[col][row]++
+				}
+			}
+		}
+
+		// Unfinished games don't count towards win rates
+		if summary.Outcome == "" {
+			continue
+		}
+		won := summary.Outcome == "win"
+		analytics.Total.add(won)
+		for key, rates := range map[string]map[string]*winRate{
+			summary.Opponent: analytics.ByOpponent,
+			summary.Layout:   analytics.ByLayout,
+			summary.Mode:     analytics.ByMode,
+		} {
+			if rates[key] == nil {
+				rates[key] = &winRate{}
+			}
+			rates[key].add(won)
+		}
+		if won {
+			shotsToWin += summary.Shots
+		}
+	}
+	if analytics.Total.Wins > 0 {
+		analytics.AvgShotsToWin = float64(shotsToWin) / float64(analytics.Total.Wins)
+	}
+	return analytics
+}
+
+func loadAnalytics() (playerAnalytics, error) {
+	paths, err := listGameRecords()
+	if err != nil {
+		return playerAnalytics{}, err
+	}
+	records := make([]*gameRecord, 0, len(paths))
+	for _, path := range paths {
+		record, err := loadGameRecord(path)
+		if err != nil {
+			continue
+		}
+		records = append(records, record)
+	}
+	return computeAnalytics(records), nil
+}
+
+// layoutName gives a short name to a ship layout so games played with it can be grouped
+func layoutName(coords []string) string {
+	sorted := make([]string, len(coords))
+	copy(sorted, coords)
+	sort.Strings(sorted)
+	hash := fnv.New32a()
+	hash.Write([]byte(strings.Join(sorted, ",")))
+	return fmt.Sprintf("L%04x", hash.Sum32()&0xffff)
+}
+
+// sortedRates returns keys of the map ordered by number of games played
+func sortedRates(rates map[string]*winRate) []string {
+	keys := make([]string, 0, len(rates))
+	for key := range rates {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if rates[keys[i]].Games != rates[keys[j]].Games {
+			return rates[keys[i]].Games > rates[keys[j]].Games
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// historyMenu shows the local match history and trends computed from it
+func historyScreen() Screen {
+	return Screen{Name: "history", Enter: historyMenu}
+}
+
+func historyMenu(ctx context.Context, app *App) {
+	historyUi := HistoryElements(app)
+
+	analytics, err := loadAnalytics()
+	if err != nil {
+		statusMessage(app, layout.Message(), app.T("error.history", err), app.Theme().Error)
+	}
+	drawAnalytics(app, analytics)
+
+	for ctx.Err() == nil {
+		clicked := app.ListenArea(ctx, historyUi.ButtonArea)
+		switch clicked {
+		case "returnButton":
+			app.Nav.Pop(ctx)
+			return
+		}
+	}
+}
+
+func drawAnalytics(app *App, analytics playerAnalytics) {
+	area := layout.Content().Pad(2).Below(7)
+	// Rows that don't fit the terminal are left out
+	draw := func(x, y int, text string) {
+		if y < area.Bottom() {
+			app.Draw(gui.NewText(x, y, text, app.Theme().Text))
+		}
+	}
+
+	// Recent games
+	lines := []string{fmt.Sprintf("%-16s %-14s %-6s %-10s %5s %8s %8s %s",
+		app.T("history.date"), app.T("history.opponent"), app.T("history.mode"), app.T("history.result"),
+		app.T("history.shots"), app.T("history.accuracy"), app.T("history.duration"), app.T("history.layout"))}
+	for i, match := range analytics.Matches {
+		if i == 8 {
+			break
+		}
+		outcome := match.Outcome
+		if outcome == "" {
+			outcome = app.T("replays.unfinished")
+		}
+		lines = append(lines, fmt.Sprintf("%-16s %-14.14s %-6s %-10s %5d %8s %8s %s",
+			match.Time.Format("2006-01-02 15:04"), match.Opponent, match.Mode, outcome,
+			match.Shots, accuracyText(match.Hits, match.Shots), match.Duration.Round(time.Second), match.Layout))
+	}
+	table := layout.Rect{X: area.X, Y: area.Y, H: len(lines)}
+	for i, line := range lines {
+		table.W = max(table.W, len(line))
+		draw(table.X, table.Y+i, line)
+	}
+
+	// Heatmap of cells where opponents hit us, counts above 9 are shown as 9
+	heatmap := layout.Rect{X: area.X, Y: table.Bottom() + 1, W: 28, H: 12}
+	draw(heatmap.X, heatmap.Y, app.T("history.heatmap"))
+	draw(heatmap.X, heatmap.Y+1, "    A B C D E F G H I J")
+	for row := 0; row < 10; row++ {
+		line := fmt.Sprintf("%3d ", row+1)
+		for col := 0; col < 10; col++ {
+			hits := analytics.HitHeatmap[col][row]
+			if hits == 0 {
+				line += ". "
+			} else {
+				line += fmt.Sprintf("%d ", min(hits, 9))
+			}
+		}
+		draw(heatmap.X, heatmap.Y+2+row, line)
+	}
+
+	// Win rates go right of the table if there's room, otherwise right of the heatmap
+	x, y := table.Right()+3, table.Y
+	if area.Right()-x < 30 {
+		x, y = heatmap.Right()+3, heatmap.Y
+	}
+	draw(x, y, app.T("history.winRate", analytics.Total))
+	draw(x, y+1, app.T("history.avgShots", analytics.AvgShotsToWin))
+	y += 3
+	for _, group := range []struct {
+		title string
+		rates map[string]*winRate
+	}{
+		{app.T("history.byMode"), analytics.ByMode},
+		{app.T("history.byOpponent"), analytics.ByOpponent},
+		{app.T("history.byLayout"), analytics.ByLayout},
+	} {
+		draw(x, y, group.title)
+		y++
+		for i, key := range sortedRates(group.rates) {
+			if i == 5 {
+				break
+			}
+			draw(x+2, y, fmt.Sprintf("%-14.14s %s", key, group.rates[key]))
+			y++
+		}
+		y++
+	}
+}
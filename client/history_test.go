@@ -0,0 +1,62 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeAnalytics(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	layout := []string{"A1", "A2"}
+	game := func(opponent, mode, outcome string, shots ...GameEvent) *gameRecord {
+		record := &gameRecord{
+			Start: GameEvent{Type: "start", Time: start, Opponent: opponent, Mode: mode, Coords: layout},
+			Shots: shots,
+		}
+		if outcome != "" {
+			record.End = GameEvent{Type: "end", Time: start.Add(time.Minute), Outcome: outcome}
+		}
+		return record
+	}
+	shot := func(shooter, coord, result string) GameEvent {
+		return GameEvent{Type: "shot", Shooter: shooter, Coord: coord, Result: result}
+	}
+
+	analytics := computeAnalytics([]*gameRecord{
+		game("alice", "pvp", "win",
+			shot("player", "B1", "hit"), shot("player", "B2", "miss"), shot("opponent", "A1", "hit"),
+			shot("player", "C1", "sunk"), shot("player", "D1", "hit")),
+		game("alice", "pvp", "lose", shot("opponent", "A1", "hit"), shot("opponent", "A2", "sunk")),
+		game("BomBot", "bombot", "win", shot("player", "E5", "sunk"), shot("player", "F5", "hit")),
+		game("bob", "pvp", "", shot("opponent", "J10", "hit")),
+	})
+
+	if len(analytics.Matches) != 4 {
+		t.Fatalf("got %d matches, want 4", len(analytics.Matches))
+	}
+	if got := analytics.Matches[0]; got.Shots != 4 || got.Hits != 3 || got.Duration != time.Minute {
+		t.Errorf("first match = %+v, want 4 shots, 3 hits and a minute", got)
+	}
+	if analytics.Total != (winRate{Games: 3, Wins: 2}) {
+		t.Errorf("total = %v, want 2/3, unfinished games don't count", analytics.Total)
+	}
+	if got := analytics.ByOpponent["alice"]; got == nil || *got != (winRate{Games: 2, Wins: 1}) {
+		t.Errorf("win rate against alice = %v, want 1/2", got)
+	}
+	if got := analytics.ByMode["bombot"]; got == nil || *got != (winRate{Games: 1, Wins: 1}) {
+		t.Errorf("win rate against BomBot = %v, want 1/1", got)
+	}
+	if _, ok := analytics.ByOpponent["bob"]; ok {
+		t.Error("unfinished game against bob counted towards win rates")
+	}
+	if got := analytics.ByLayout[layoutName(layout)]; got == nil || got.Games != 3 {
+		t.Errorf("win rate of the layout = %v, want 3 games", got)
+	}
+	if analytics.AvgShotsToWin != 3 {
+		t.Errorf("average shots to win = %v, want 3", analytics.AvgShotsToWin)
+	}
+	if analytics.HitHeatmap[0][0] != 2 || analytics.HitHeatmap[0][1] != 1 || analytics.HitHeatmap[9][9] != 1 {
+		t.Errorf("heatmap A1=%d A2=%d J10=%d, want 2, 1 and 1",
+			analytics.HitHeatmap[0][0], analytics.HitHeatmap[0][1], analytics.HitHeatmap[9][9])
+	}
+}
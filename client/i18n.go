@@ -0,0 +1,40 @@
+package client
+
+import "fmt"
+
+// Languages with a message catalog, the first one is the default and is used for messages missing in others
+var languages = []string{"en", "pl"}
+
+// catalogs map message keys to messages of each language, messages may contain fmt verbs
+var catalogs = map[string]map[string]string{
+	"en": messagesEN,
+	"pl": messagesPL,
+}
+
+// T returns the message for the key in the language of the app, formatted with args
+func (a *App) T(key string, args ...any) string {
+	message, ok := catalogs[a.Language()][key]
+	if !ok {
+		message, ok = catalogs[languages[0]][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
+
+// shotResult returns the word for a result of a shot, results are hit, miss and sunk as the server sends them
+func shotResult(app *App, result string) string {
+	return app.T("shot." + result)
+}
+
+// onOff returns the word for a switch being on or off
+func onOff(app *App, on bool) string {
+	if on {
+		return app.T("settings.on")
+	}
+	return app.T("settings.off")
+}
@@ -0,0 +1,176 @@
+package client
+
+import (
+	"slices"
+	"sort"
+
+	gui "github.com/s25867/warships-gui/v2"
+)
+
+type cellKnowledge int
+
+const (
+	cellUnknown cellKnowledge = iota
+	cellMiss
+	cellHit // hit of a ship that isn't known to be sunk yet
+	cellSunk
+	cellInferredEmpty // never shot at, but no ship can be here
+)
+
+// targetGrid is what a shooter knows about the opponent board. Ships never touch,
+// not even diagonally, so hits next to each other always belong to the same ship.
+type targetGrid [10][10]cellKnowledge
+
+// record adds the result of a shot and returns the cells of the ship it sank, if the
+// ship is known. A sunk ship is known when the hits connected to the shot make up a
+// ship that is still afloat, then the cells around it are inferred empty. Otherwise
+// the hits stay unresolved and nothing is inferred from them.
+func (g *targetGrid) record(coord, result string) [][2]int {
+	col, row, err := coordToIndex(coord)
+	if err != nil {
+		return nil
+	}
+	switch result {
+	case "miss":
+		g[col][row] = cellMiss
+	case "hit":
+		g[col][row] = cellHit
+	case "sunk":
+		g[col][row] = cellHit
+		ship := g.connected(col, row, cellHit)
+		if !slices.Contains(g.afloatSizes(), len(ship)) {
+			return nil
+		}
+		for _, cell := range ship {
+			g[cell[0]][cell[1]] = cellSunk
+		}
+		for _, cell := range ship {
+			g.inferAround(cell[0], cell[1])
+		}
+		return ship
+	}
+	return nil
+}
+
+// connected returns all cells in the given state orthogonally connected to the given one
+func (g *targetGrid) connected(col, row int, state cellKnowledge) [][2]int {
+	visited := map[[2]int]bool{{col, row}: true}
+	queue := [][2]int{{col, row}}
+	for i := 0; i < len(queue); i++ {
+		for _, next := range orthogonalNeighbours(queue[i][0], queue[i][1]) {
+			if !visited[next] && g[next[0]][next[1]] == state {
+				visited[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return queue
+}
+
+// inferAround marks unknown cells around a sunk ship cell as inferred empty
+func (g *targetGrid) inferAround(col, row int) {
+	for i := col - 1; i <= col+1; i++ {
+		for j := row - 1; j <= row+1; j++ {
+			if i >= 0 && i <= 9 && j >= 0 && j <= 9 && g[i][j] == cellUnknown {
+				g[i][j] = cellInferredEmpty
+			}
+		}
+	}
+}
+
+// afloatSizes returns sizes of the fleet that aren't known to be sunk
+func (g *targetGrid) afloatSizes() []int {
+	left := slices.Clone(fleetSizes)
+	counted := map[[2]int]bool{}
+	for col := 0; col < 10; col++ {
+		for row := 0; row < 10; row++ {
+			if g[col][row] != cellSunk || counted[[2]int{col, row}] {
+				continue
+			}
+			ship := g.connected(col, row, cellSunk)
+			for _, cell := range ship {
+				counted[cell] = true
+			}
+			if i := slices.Index(left, len(ship)); i >= 0 {
+				left = slices.Delete(left, i, i+1)
+			}
+		}
+	}
+	return left
+}
+
+// known reports whether the cell was shot at or can't hold a ship
+func (g *targetGrid) known(coord string) bool {
+	col, row, err := coordToIndex(coord)
+	return err == nil && g[col][row] != cellUnknown
+}
+
+func (g *targetGrid) unknownCells() [][2]int {
+	cells := make([][2]int, 0, 100)
+	for col := 0; col < 10; col++ {
+		for row := 0; row < 10; row++ {
+			if g[col][row] == cellUnknown {
+				cells = append(cells, [2]int{col, row})
+			}
+		}
+	}
+	return cells
+}
+
+// targetCells returns unknown cells next to hits that don't belong to a sunk ship yet
+func (g *targetGrid) targetCells() [][2]int {
+	found := make(map[[2]int]bool)
+	for col := 0; col < 10; col++ {
+		for row := 0; row < 10; row++ {
+			if g[col][row] != cellHit {
+				continue
+			}
+			for _, next := range orthogonalNeighbours(col, row) {
+				if g[next[0]][next[1]] == cellUnknown {
+					found[next] = true
+				}
+			}
+		}
+	}
+	cells := make([][2]int, 0, len(found))
+	for cell := range found {
+		cells = append(cells, cell)
+	}
+	// Map order is random, sort so that only rand decides which cell is picked
+	sort.Slice(cells, func(i, j int) bool {
+		return cells[i][0]*10+cells[i][1] < cells[j][0]*10+cells[j][1]
+	})
+	return cells
+}
+
+// states returns the opponent board as it's drawn. The board has no state of its own for
+// inferred empty cells, they are drawn as misses since no ship can be there either way.
+func (g *targetGrid) states() [10][10]gui.State {
+	var states [10][10]gui.State
+	for col := 0; col < 10; col++ {
+		for row := 0; row < 10; row++ {
+			switch g[col][row] {
+			case cellMiss, cellInferredEmpty:
+				states[col][row] = gui.Miss
+			case cellHit:
+				states[col][row] = gui.Hit
+			case cellSunk:
+				states[col][row] = gui.Sunk
+			default:
+				states[col][row] = gui.Empty
+			}
+		}
+	}
+	return states
+}
+
+func orthogonalNeighbours(col, row int) [][2]int {
+	neighbours := make([][2]int, 0, 4)
+	for _, d := range [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+		c, r := col+d[0], row+d[1]
+		if c >= 0 && c <= 9 && r >= 0 && r <= 9 {
+			neighbours = append(neighbours, [2]int{c, r})
+		}
+	}
+	return neighbours
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"testing"
+)
+
+func TestTargetGridRecord(t *testing.T) {
+	tests := []struct {
+		name      string
+		shots     [][2]string // coordinate and result
+		wantSunk  int         // size of the ship the last shot sank
+		wantCells map[string]cellKnowledge
+	}{
+		{
+			name:      "miss",
+			shots:     [][2]string{{"B2", "miss"}},
+			wantCells: map[string]cellKnowledge{"B2": cellMiss, "B3": cellUnknown},
+		},
+		{
+			name:      "hit infers nothing",
+			shots:     [][2]string{{"B2", "hit"}},
+			wantCells: map[string]cellKnowledge{"B2": cellHit, "C3": cellUnknown},
+		},
+		{
+			name:     "sunk ship marks cells around it",
+			shots:    [][2]string{{"B2", "hit"}, {"B3", "sunk"}},
+			wantSunk: 2,
+			wantCells: map[string]cellKnowledge{
+				"B2": cellSunk, "B3": cellSunk,
+				"A1": cellInferredEmpty, "C4": cellInferredEmpty, "B4": cellInferredEmpty,
+				"B5": cellUnknown,
+			},
+		},
+		{
+			name:     "cells shot before stay as they were",
+			shots:    [][2]string{{"A1", "miss"}, {"B2", "sunk"}},
+			wantSunk: 1,
+			wantCells: map[string]cellKnowledge{
+				"A1": cellMiss, "B2": cellSunk, "C3": cellInferredEmpty,
+			},
+		},
+		{
+			name: "sunk ship of a size that is already sunk is left unresolved",
+			shots: [][2]string{
+				{"A1", "hit"}, {"A2", "hit"}, {"A3", "hit"}, {"A4", "sunk"},
+				{"J1", "hit"}, {"J2", "hit"}, {"J3", "hit"}, {"J4", "sunk"},
+			},
+			wantCells: map[string]cellKnowledge{
+				"A4": cellSunk, "J4": cellHit, "I1": cellUnknown,
+			},
+		},
+		{
+			name:      "invalid coordinate is ignored",
+			shots:     [][2]string{{"K1", "hit"}},
+			wantCells: map[string]cellKnowledge{"J1": cellUnknown},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var grid targetGrid
+			var sunk [][2]int
+			for _, shot := range tt.shots {
+				sunk = grid.record(shot[0], shot[1])
+			}
+			if len(sunk) != tt.wantSunk {
+				t.Errorf("last shot sank a ship of size %d, want %d", len(sunk), tt.wantSunk)
+			}
+			for coord, want := range tt.wantCells {
+				col, row, _ := coordToIndex(coord)
+				if got := grid[col][row]; got != want {
+					t.Errorf("cell %s = %v, want %v", coord, got, want)
+				}
+			}
+		})
+	}
+}
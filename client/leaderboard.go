@@ -0,0 +1,199 @@
+package client
+
+import (
+	"BomboweStatki/layout"
+	"context"
+	"sort"
+	"strings"
+
+	gui "github.com/s25867/warships-gui/v2"
+)
+
+// Columns the leaderboard can be sorted by
+var leaderboardSorts = []string{"Rank", "Points", "Wins", "Games", "Win %"}
+
+// Choices of the minimum number of games filter
+var leaderboardMinGames = []int{0, 5, 10, 20, 50}
+
+const leaderboardPageSize = 15
+
+// leaderboardQuery is what the leaderboard screen shows, it's kept between redraws
+type leaderboardQuery struct {
+	Sort     string
+	Search   string // part of the nick, case insensitive
+	MinGames int
+	Page     int
+}
+
+// WinRate returns the percentage of games won
+func (s PlayerStats) WinRate() float64 {
+	if s.Games == 0 {
+		return 0
+	}
+	return float64(s.Wins) / float64(s.Games) * 100
+}
+
+// filterPlayers returns players matching the query sorted by the chosen column,
+// rank is sorted from the best, other columns from the highest
+func filterPlayers(players []PlayerStats, query leaderboardQuery) []PlayerStats {
+	search := strings.ToLower(query.Search)
+	filtered := make([]PlayerStats, 0, len(players))
+	for _, player := range players {
+		if player.Games >= query.MinGames && strings.Contains(strings.ToLower(player.Nick), search) {
+			filtered = append(filtered, player)
+		}
+	}
+
+	less := map[string]func(a, b PlayerStats) bool{
+		"Rank":   func(a, b PlayerStats) bool { return a.Rank < b.Rank },
+		"Points": func(a, b PlayerStats) bool { return a.Points > b.Points },
+		"Wins":   func(a, b PlayerStats) bool { return a.Wins > b.Wins },
+		"Games":  func(a, b PlayerStats) bool { return a.Games > b.Games },
+		"Win %":  func(a, b PlayerStats) bool { return a.WinRate() > b.WinRate() },
+	}[query.Sort]
+	if less == nil {
+		less = func(a, b PlayerStats) bool { return a.Rank < b.Rank }
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		if less(filtered[i], filtered[j]) != less(filtered[j], filtered[i]) {
+			return less(filtered[i], filtered[j])
+		}
+		return filtered[i].Nick < filtered[j].Nick
+	})
+	return filtered
+}
+
+func pageCount(players int) int {
+	return (players + leaderboardPageSize - 1) / leaderboardPageSize
+}
+
+// pageOf returns the page the player is on or -1 if the player isn't listed
+func pageOf(players []PlayerStats, nick string) int {
+	for i, player := range players {
+		if player.Nick == nick {
+			return i / leaderboardPageSize
+		}
+	}
+	return -1
+}
+
+// leaderboardScreen shows the players, if players is nil they're fetched from the server
+func leaderboardScreen(query leaderboardQuery, players []PlayerStats) Screen {
+	return Screen{Name: "leaderboard", Enter: func(ctx context.Context, app *App) {
+		leaderboardMenu(ctx, app, query, players)
+	}}
+}
+
+func leaderboardMenu(ctx context.Context, app *App, query leaderboardQuery, players []PlayerStats) {
+	if players == nil {
+		var err error
+		players, err = app.API.GetStats()
+		if err != nil {
+			statusMessage(app, layout.Message(), app.T("error.stats", err), app.Theme().Error)
+			players = []PlayerStats{}
+		}
+	}
+
+	filtered := filterPlayers(players, query)
+	pages := pageCount(len(filtered))
+	query.Page = max(min(query.Page, pages-1), 0)
+	start := query.Page * leaderboardPageSize
+	rows := filtered[start:min(start+leaderboardPageSize, len(filtered))]
+	leaderboardUi := LeaderboardElements(app, query, rows, pages)
+
+	// Every change of the query redraws the screen with the same players
+	redraw := func(query leaderboardQuery) {
+		app.Nav.Replace(ctx, leaderboardScreen(query, players))
+	}
+
+	for ctx.Err() == nil {
+		clicked := app.ListenArea(ctx, leaderboardUi.ButtonArea)
+		switch {
+		case clicked == "returnButton":
+			app.Nav.Pop(ctx)
+			return
+		case strings.HasPrefix(clicked, "sort:"):
+			query.Sort = strings.TrimPrefix(clicked, "sort:")
+			query.Page = 0
+			redraw(query)
+			return
+		case strings.HasPrefix(clicked, "player:"):
+			app.Nav.Push(ctx, playerDetailScreen(strings.TrimPrefix(clicked, "player:")))
+			return
+		case clicked == "searchButton":
+			query.Search = strings.TrimSpace(app.ReadInput(leaderboardUi.SearchField))
+			query.Page = 0
+			redraw(query)
+			return
+		case clicked == "clearButton":
+			query.Search, query.MinGames, query.Page = "", 0, 0
+			redraw(query)
+			return
+		case clicked == "minGamesButton":
+			query.MinGames = nextInCycle(leaderboardMinGames, query.MinGames)
+			query.Page = 0
+			redraw(query)
+			return
+		case clicked == "prevButton" && query.Page > 0:
+			query.Page--
+			redraw(query)
+			return
+		case clicked == "nextButton" && query.Page < pages-1:
+			query.Page++
+			redraw(query)
+			return
+		case clicked == "meButton":
+			nick := app.Profile().Nick
+			page := pageOf(filtered, nick)
+			if page == -1 {
+				// We may be hidden by the filters, look in the whole list
+				query.Search, query.MinGames = "", 0
+				page = pageOf(filterPlayers(players, query), nick)
+			}
+			if page == -1 {
+				statusMessage(app, layout.Message(), nick+" is not on the leaderboard yet", app.Theme().Error)
+				continue
+			}
+			query.Page = page
+			redraw(query)
+			return
+		}
+	}
+}
+
+func playerDetailScreen(nick string) Screen {
+	return Screen{Name: "player", Enter: func(ctx context.Context, app *App) {
+		playerDetail(ctx, app, nick)
+	}}
+}
+
+// playerDetail shows the server stats of one player
+func playerDetail(ctx context.Context, app *App, nick string) {
+	detailUi := PlayerDetailElements(app, nick)
+
+	playerStats, err := retryOnErrorWithPlayerStats(func() (PlayerStats, error) {
+		return app.API.GetPlayerStats(nick)
+	})
+	if err != nil {
+		statusMessage(app, layout.Message(), app.T("error.playerStats", err), app.Theme().Error)
+	} else {
+		lines := []string{
+			app.T("stats.rank", playerStats.Rank),
+			app.T("stats.points", playerStats.Points),
+			app.T("stats.games", playerStats.Games),
+			app.T("stats.wins", playerStats.Wins),
+			app.T("stats.losses", playerStats.Games-playerStats.Wins),
+			app.T("stats.winRate", playerStats.WinRate()),
+		}
+		for i, line := range lines {
+			app.Draw(gui.NewText(2, 8+i, line, app.Theme().Text))
+		}
+	}
+
+	for ctx.Err() == nil {
+		if app.ListenArea(ctx, detailUi.ButtonArea) == "returnButton" {
+			app.Nav.Pop(ctx)
+			return
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFilterPlayers(t *testing.T) {
+	players := []PlayerStats{
+		{Nick: "Alice", Rank: 2, Points: 900, Games: 10, Wins: 5},
+		{Nick: "bob", Rank: 1, Points: 1000, Games: 4, Wins: 4},
+		{Nick: "Carol", Rank: 3, Points: 800, Games: 20, Wins: 5},
+		{Nick: "alex", Rank: 4, Points: 800, Games: 6, Wins: 3},
+	}
+	tests := []struct {
+		name  string
+		query leaderboardQuery
+		want  []string
+	}{
+		{"rank by default", leaderboardQuery{}, []string{"bob", "Alice", "Carol", "alex"}},
+		{"unknown sort is rank", leaderboardQuery{Sort: "Nope"}, []string{"bob", "Alice", "Carol", "alex"}},
+		{"points, ties by nick", leaderboardQuery{Sort: "Points"}, []string{"bob", "Alice", "Carol", "alex"}},
+		{"games", leaderboardQuery{Sort: "Games"}, []string{"Carol", "Alice", "alex", "bob"}},
+		{"win rate", leaderboardQuery{Sort: "Win %"}, []string{"bob", "Alice", "alex", "Carol"}},
+		{"search ignores case", leaderboardQuery{Search: "AL"}, []string{"Alice", "alex"}},
+		{"min games", leaderboardQuery{MinGames: 10}, []string{"Alice", "Carol"}},
+		{"no match", leaderboardQuery{Search: "zed"}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nicks := []string{}
+			for _, player := range filterPlayers(players, tt.query) {
+				nicks = append(nicks, player.Nick)
+			}
+			if !slices.Equal(nicks, tt.want) {
+				t.Errorf("filterPlayers() = %v, want %v", nicks, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,271 @@
+package client
+
+import (
+	"BomboweStatki/layout"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	gui "github.com/s25867/warships-gui/v2"
+)
+
+// The log file starts over when it reaches maxLogSize, the previous
+// maxLogFiles files are kept as statki.log.1, statki.log.2 and so on
+const (
+	logsDir     = "logs"
+	logName     = "statki.log"
+	maxLogSize  = 1 << 20
+	maxLogFiles = 3
+)
+
+// Number of the last messages shown in the log panel, one on each of its rows
+const logPanelLines = layout.LogRows
+
+// SetupLogging sends the default slog logger to the rotating log file. Requests, retries,
+// screen and game state changes and bot decisions are logged at debug and info level,
+// problems at warn and error level. Verbose logging includes debug messages in the file
+// and info messages in the log panel, which otherwise only shows warnings and errors.
+// The returned history is passed to the App, which shows it in the log panel. If the
+// file can't be opened the error is returned and only the log panel gets messages.
+func SetupLogging(verbose bool) (logs *LogHistory, close func() error, err error) {
+	var out io.Writer = io.Discard
+	close = func() error { return nil }
+	file, err := openRotatingFile(filepath.Join(logsDir, logName))
+	if err == nil {
+		out, close = file, file.Close
+	}
+
+	fileLevel, panelLevel := slog.LevelInfo, slog.LevelWarn
+	if verbose {
+		fileLevel, panelLevel = slog.LevelDebug, slog.LevelInfo
+	}
+	logs = &LogHistory{}
+	slog.SetDefault(slog.New(&historyHandler{
+		next:    slog.NewTextHandler(out, &slog.HandlerOptions{Level: fileLevel}),
+		level:   panelLevel,
+		history: logs,
+	}))
+	return logs, close, err
+}
+
+// rotatingFile is a log file that is moved aside and started over when it grows too big
+type rotatingFile struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+	size int64
+}
+
+func openRotatingFile(path string) (*rotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("error creating logs directory: %w", err)
+	}
+	f := &rotatingFile{path: path}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("error opening log file: %w", err)
+	}
+	f.file, f.size = file, info.Size()
+	return nil
+}
+
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.size+int64(len(p)) > maxLogSize && f.size > 0 {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// rotate shifts the older files by one, dropping the oldest, and starts a new file
+func (f *rotatingFile) rotate() error {
+	f.file.Close()
+	for i := maxLogFiles - 1; i > 0; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", f.path, i), fmt.Sprintf("%s.%d", f.path, i+1))
+	}
+	os.Rename(f.path, f.path+".1")
+	return f.open()
+}
+
+func (f *rotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
+
+// historyHandler passes records to the file handler and keeps the last
+// ones at or above its level for the log panel
+type historyHandler struct {
+	next    slog.Handler
+	level   slog.Level
+	history *LogHistory
+	attrs   []slog.Attr // from WithAttrs, shown before attributes of the record
+	group   string      // from WithGroup, prefixes keys of attributes added later
+}
+
+func (h *historyHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level || h.next.Enabled(ctx, level)
+}
+
+func (h *historyHandler) Handle(ctx context.Context, record slog.Record) error {
+	if record.Level >= h.level {
+		h.history.add(record, h.attrs, h.group)
+	}
+	if !h.next.Enabled(ctx, record.Level) {
+		return nil
+	}
+	return h.next.Handle(ctx, record)
+}
+
+func (h *historyHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handler := *h
+	handler.next = h.next.WithAttrs(attrs)
+	handler.attrs = append(slices.Clip(h.attrs), groupAttrs(h.group, attrs)...)
+	return &handler
+}
+
+func (h *historyHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	handler := *h
+	handler.next = h.next.WithGroup(name)
+	handler.group = groupKey(h.group, name)
+	return &handler
+}
+
+// groupAttrs returns the attributes with keys prefixed by the group
+func groupAttrs(group string, attrs []slog.Attr) []slog.Attr {
+	grouped := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		grouped[i] = slog.Attr{Key: groupKey(group, attr.Key), Value: attr.Value}
+	}
+	return grouped
+}
+
+func groupKey(group, key string) string {
+	if group == "" {
+		return key
+	}
+	return group + "." + key
+}
+
+// LogHistory holds the last messages shown in the log panel
+type LogHistory struct {
+	mu       sync.Mutex
+	lines    []logLine
+	onChange func(lines []logLine)
+}
+
+type logLine struct {
+	level slog.Level
+	text  string
+}
+
+// add keeps the record with attributes of the handler, attributes of the record are in the group
+func (m *LogHistory) add(record slog.Record, attrs []slog.Attr, group string) {
+	text := record.Time.Format("15:04:05") + " " + record.Level.String() + " " + record.Message
+	for _, attr := range attrs {
+		text += " " + attr.String()
+	}
+	record.Attrs(func(attr slog.Attr) bool {
+		text += " " + slog.Attr{Key: groupKey(group, attr.Key), Value: attr.Value}.String()
+		return true
+	})
+
+	m.mu.Lock()
+	m.lines = append(m.lines, logLine{level: record.Level, text: text})
+	if len(m.lines) > logPanelLines {
+		m.lines = m.lines[len(m.lines)-logPanelLines:]
+	}
+	lines, onChange := append([]logLine(nil), m.lines...), m.onChange
+	m.mu.Unlock()
+
+	if onChange != nil {
+		onChange(lines)
+	}
+}
+
+// logPanel shows the last log messages at the bottom of the current screen, each
+// message moves the older ones up instead of overwriting a single line
+type logPanel struct {
+	app *App
+
+	mu    sync.Mutex
+	drawn []gui.Drawable
+}
+
+// startLogPanel draws new messages of the log history on the screens of the app
+func startLogPanel(app *App) {
+	if app.logs == nil {
+		return
+	}
+	panel := &logPanel{app: app}
+	app.logs.mu.Lock()
+	app.logs.onChange = panel.draw
+	app.logs.mu.Unlock()
+}
+
+func (p *logPanel) draw(lines []logLine) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, drawable := range p.drawn {
+		p.app.Remove(drawable)
+	}
+	p.drawn = p.drawn[:0]
+
+	area := layout.Log()
+	area.X, area.W = area.X+1, max(area.W-2, 0)
+	width := area.W
+	y := area.Bottom() - len(lines)
+	for i, line := range lines {
+		config := p.app.Theme().MutedText
+		if line.level >= slog.LevelWarn {
+			config = p.app.Theme().Error
+		}
+		text := gui.NewText(area.X, y+i, fmt.Sprintf("%-*.*s", width, width, line.text), config)
+		p.app.Draw(text)
+		p.drawn = append(p.drawn, text)
+	}
+}
+
+// loggingTransport logs every request to the server at debug level
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+func (t loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	attrs := []any{"method", req.Method, "path", req.URL.Path, "duration", time.Since(start).Round(time.Millisecond)}
+	if err != nil {
+		slog.Debug("request failed", append(attrs, "err", err)...)
+		return resp, err
+	}
+	slog.Debug("request", append(attrs, "status", resp.StatusCode)...)
+	return resp, nil
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), logsDir, logName)
+	file, err := openRotatingFile(path)
+	if err != nil {
+		t.Fatalf("openRotatingFile() error = %v", err)
+	}
+	defer file.Close()
+
+	chunk := bytes.Repeat([]byte("x"), maxLogSize/2+1)
+	for i := 0; i < maxLogFiles+2; i++ {
+		if _, err := file.Write(chunk); err != nil {
+			t.Fatalf("write %d: %v", i+1, err)
+		}
+	}
+	if info, err := os.Stat(path); err != nil || info.Size() != int64(len(chunk)) {
+		t.Errorf("current log file %v, error %v, want a single chunk", info, err)
+	}
+	for i := 1; i <= maxLogFiles; i++ {
+		if _, err := os.Stat(fmt.Sprintf("%s.%d", path, i)); err != nil {
+			t.Errorf("rotated file %d is missing: %v", i, err)
+		}
+	}
+	if _, err := os.Stat(fmt.Sprintf("%s.%d", path, maxLogFiles+1)); err == nil {
+		t.Errorf("more than %d rotated files are kept", maxLogFiles)
+	}
+}
+
+func TestHistoryHandler(t *testing.T) {
+	logs := &LogHistory{}
+	logger := slog.New(&historyHandler{
+		next:    slog.NewTextHandler(io.Discard, nil),
+		level:   slog.LevelWarn,
+		history: logs,
+	})
+
+	logger.With("nick", "alice").WithGroup("shot").Warn("retrying", "coord", "B2")
+	logs.mu.Lock()
+	lines := logs.lines
+	logs.mu.Unlock()
+	if len(lines) != 1 || !strings.HasSuffix(lines[0].text, "WARN retrying nick=alice shot.coord=B2") {
+		t.Errorf("lines = %v, want the message with its attributes", lines)
+	}
+
+	// Messages below the level are left out, older ones make room for new ones
+	logger.Info("not shown")
+	for i := 0; i < logPanelLines; i++ {
+		logger.Error("failed")
+	}
+	logger.Log(context.Background(), slog.LevelWarn, "last")
+
+	logs.mu.Lock()
+	lines = logs.lines
+	logs.mu.Unlock()
+	if len(lines) != logPanelLines {
+		t.Fatalf("history has %d lines, want %d", len(lines), logPanelLines)
+	}
+	if !strings.HasSuffix(lines[len(lines)-1].text, "WARN last") {
+		t.Errorf("last line = %q, want the last message", lines[len(lines)-1].text)
+	}
+}
@@ -0,0 +1,228 @@
+package client
+
+import (
+	"BomboweStatki/layout"
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+// QuickMatchSettings are the quick match options saved in the profile
+type QuickMatchSettings struct {
+	Wait                int    `json:"wait"`     // seconds to search before falling back
+	Fallback            string `json:"fallback"` // lobby or wpbot
+	ExcludeRecentLosses bool   `json:"exclude_recent_losses"`
+}
+
+var defaultQuickMatch = QuickMatchSettings{Wait: 30, Fallback: "lobby"}
+
+// Choices the quick match buttons cycle through
+var (
+	quickMatchWaits     = []int{15, 30, 60, 120}
+	quickMatchFallbacks = []string{"lobby", "wpbot"}
+)
+
+// Games lost within this time are recent, their opponents can be skipped
+const recentLossPeriod = 24 * time.Hour
+
+// How often the lobby is checked while searching
+const quickMatchPollInterval = 2 * time.Second
+
+// pickOpponent returns the waiting player whose points are closest to ours.
+// Players without stats have no points yet, ties go to the nick that sorts first.
+func pickOpponent(players []Player, stats []PlayerStats, nick string, excluded map[string]bool) (string, bool) {
+	points := make(map[string]int)
+	for _, playerStats := range stats {
+		points[playerStats.Nick] = playerStats.Points
+	}
+	ourPoints := points[nick]
+
+	candidates := make([]string, 0)
+	for _, player := range players {
+		if player.GameStatus == "waiting" && player.Nick != nick && !excluded[player.Nick] {
+			candidates = append(candidates, player.Nick)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", false
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		di, dj := abs(points[candidates[i]]-ourPoints), abs(points[candidates[j]]-ourPoints)
+		if di != dj {
+			return di < dj
+		}
+		return candidates[i] < candidates[j]
+	})
+	return candidates[0], true
+}
+
+// recentLosses returns opponents we lost to in locally recorded games since the given time
+func recentLosses(since time.Time) map[string]bool {
+	losses := make(map[string]bool)
+	analytics, err := loadAnalytics()
+	if err != nil {
+		return losses
+	}
+	for _, match := range analytics.Matches {
+		if match.Outcome == "lose" && match.Time.After(since) {
+			losses[match.Opponent] = true
+		}
+	}
+	return losses
+}
+
+func quickMatchScreen() Screen {
+	return Screen{Name: "quickmatch", Enter: quickMatchMenu}
+}
+
+func quickMatchMenu(ctx context.Context, app *App) {
+	settings := app.Profile().QuickMatch
+	quickMatchUi := QuickMatchElements(app, settings)
+
+	clicks := make(chan string)
+	go func() {
+		for ctx.Err() == nil {
+			clicked := app.ListenArea(ctx, quickMatchUi.ButtonArea)
+			select {
+			case clicks <- clicked:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	// The search runs in the background until it starts a game, is stopped, fails or the screen is left
+	searching := false
+	cancelSearch := func() {}
+	defer func() { cancelSearch() }()
+	failed := make(chan error)
+
+	for {
+		var clicked string
+		select {
+		case <-ctx.Done():
+			return
+		case err := <-failed:
+			cancelSearch()
+			searching = false
+			statusMessage(app, quickMatchUi.Status, err.Error(), app.Theme().Error)
+			continue
+		case clicked = <-clicks:
+		}
+		switch clicked {
+		case "returnButton":
+			app.Nav.Pop(ctx)
+			return
+		case "waitButton", "fallbackButton", "excludeButton":
+			if searching {
+				statusMessage(app, quickMatchUi.Status, app.T("quick.stopFirst"), app.Theme().Error)
+				continue
+			}
+			app.updateProfile(func(profile *Profile) {
+				switch clicked {
+				case "waitButton":
+					profile.QuickMatch.Wait = nextInCycle(quickMatchWaits, profile.QuickMatch.Wait)
+				case "fallbackButton":
+					profile.QuickMatch.Fallback = nextInCycle(quickMatchFallbacks, profile.QuickMatch.Fallback)
+				case "excludeButton":
+					profile.QuickMatch.ExcludeRecentLosses = !profile.QuickMatch.ExcludeRecentLosses
+				}
+			})
+			app.Nav.Replace(ctx, quickMatchScreen())
+			return
+		case "searchButton":
+			if !searching {
+				searchCtx, cancel := context.WithCancel(ctx)
+				searching, cancelSearch = true, cancel
+				go func() {
+					if err := searchOpponent(searchCtx, app, quickMatchUi.Status, settings); err != nil {
+						select {
+						case failed <- err:
+						case <-searchCtx.Done():
+						}
+					}
+				}()
+			}
+		case "stopButton":
+			if searching {
+				cancelSearch()
+				searching = false
+				statusMessage(app, quickMatchUi.Status, app.T("quick.stopped"), app.Theme().Text)
+			}
+		}
+	}
+}
+
+// nextInCycle returns the choice after current, or the first one if current isn't a choice
+func nextInCycle[T comparable](choices []T, current T) T {
+	for i, choice := range choices {
+		if choice == current {
+			return choices[(i+1)%len(choices)]
+		}
+	}
+	return choices[0]
+}
+
+// searchOpponent watches the lobby and challenges the best opponent, after the wait
+// is over it joins the lobby or starts a game with wpBot instead. Errors starting
+// the game or joining the lobby end the search, they are returned ready to be shown.
+// Progress of the search is shown in status.
+func searchOpponent(ctx context.Context, app *App, status layout.Rect, settings QuickMatchSettings) error {
+	gameData := app.GameData()
+	excluded := make(map[string]bool)
+	if settings.ExcludeRecentLosses {
+		excluded = recentLosses(time.Now().Add(-recentLossPeriod))
+	}
+
+	deadline := time.Now().Add(time.Duration(settings.Wait) * time.Second)
+	var stats []PlayerStats
+	for {
+		// Stats change only after games end, they're fetched once unless it failed
+		if stats == nil {
+			stats, _ = app.API.GetStats()
+		}
+		players, _, err := app.API.GetLobbyInfo()
+		if err != nil {
+			statusMessage(app, status, app.T("error.lobbyInfo", err), app.Theme().Error)
+		} else if opponent, ok := pickOpponent(players, stats, gameData.Nick, excluded); ok {
+			statusMessage(app, status, app.T("quick.challenging", opponent), app.Theme().Text)
+			gameData.TargetNick = opponent
+			return startSearchedGame(ctx, app, gameData)
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			break
+		}
+		statusMessage(app, status, app.T("quick.searching", int(remaining.Seconds())), app.Theme().Text)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(quickMatchPollInterval):
+		}
+	}
+
+	if settings.Fallback == "wpbot" {
+		statusMessage(app, status, app.T("quick.fallbackBot"), app.Theme().Text)
+		gameData.Wpbot = true
+		return startSearchedGame(ctx, app, gameData)
+	}
+
+	statusMessage(app, status, app.T("quick.fallbackLobby"), app.Theme().Text)
+	playerToken, err := retryOnError(func() (string, error) {
+		return app.API.InitGame(gameData)
+	})
+	if err != nil {
+		return errors.New(app.T("error.joinLobby", err))
+	}
+	app.Nav.Replace(ctx, lobbyScreen(newLobbySession(app, playerToken, gameData)))
+	return nil
+}
+
+// startSearchedGame starts the game the search settled on, its error is ready to be shown
+func startSearchedGame(ctx context.Context, app *App, gameData GameInitData) error {
+	if err := StartGame(ctx, app, gameData); err != nil {
+		return errors.New(app.T("error.startGame", err))
+	}
+	return nil
+}
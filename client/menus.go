@@ -1,190 +1,474 @@
 package client
 
 import (
+	"BomboweStatki/layout"
 	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
 
 	gui "github.com/s25867/warships-gui/v2"
 )
 
-func MainMenu(ui *gui.GUI) {
-	ui.NewScreen("menu")
-	ui.SetScreen("menu")
-
-	menuUi := MainMenuElements(ui)
+func mainMenuScreen() Screen {
+	return Screen{Name: "menu", Enter: MainMenu, Reflow: true}
+}
 
-	ctx := context.Background()
+func MainMenu(ctx context.Context, app *App) {
+	menuUi := MainMenuElements(app)
 
-	go printTopPlayers(ui, 30, 3)
+	go printTopPlayers(app, menuUi.TopPlayers.X, menuUi.TopPlayers.Y)
 
 	// Handle button clicks
 	for {
-		clicked := menuUi.ButtonArea.Listen(ctx)
+		clicked := app.ListenArea(ctx, menuUi.ButtonArea)
 		switch clicked {
 		case "pvpButtton":
-			timerContext, cancelTimer := context.WithCancel(context.Background())
-			reset := make(chan bool)
-			pvpMenu(ui, "", timerContext, cancelTimer, reset)
+			app.Nav.Push(ctx, lobbyScreen(nil))
 			return
 		case "botButtton":
-
-			go botMenu(ui)
+			app.Nav.Push(ctx, botScreen())
 			return
 		case "profileButton":
-			go profileMenu(ui)
+			app.Nav.Push(ctx, profileScreen())
+			return
+		case "replaysButton":
+			app.Nav.Push(ctx, replaysScreen())
+			return
+		case "quickMatchButton":
+			app.Nav.Push(ctx, quickMatchScreen())
+			return
+		case "leaderboardButton":
+			app.Nav.Push(ctx, leaderboardScreen(leaderboardQuery{Sort: "Rank"}, nil))
+			return
+		}
+		if ctx.Err() != nil {
+			return
 		}
 	}
 }
-func profileMenu(ui *gui.GUI) {
-	ui.NewScreen("profile")
-	ui.SetScreen("profile")
 
-	profileUi := ProfileElements(ui)
+func profileScreen() Screen {
+	return Screen{Name: "profile", Enter: profileMenu, Reflow: true}
+}
 
-	ctx := context.Background()
+func profileMenu(ctx context.Context, app *App) {
+	profileUi := ProfileElements(app)
 
-	printPlayerStats(ui, DefaultGameInitData.Nick, 80, 11)
+	printPlayerStats(app, app.Profile().Nick, profileUi.Stats.X, profileUi.Stats.Y)
 
-	// Handle button clicks
+	// Handle button clicks, after an edit the screen is replaced to show new values
 	for {
-		clicked := profileUi.ButtonArea.Listen(ctx)
+		clicked := app.ListenArea(ctx, profileUi.ButtonArea)
 		switch clicked {
 		case "returnButton":
-
-			go MainMenu(ui)
+			app.Nav.Pop(ctx)
 			return
 		case "boardButton":
-			go func() {
-				changeShipLayout(ui)
-			}()
+			app.Nav.Push(ctx, editorScreen())
 			return
 		case "editNameButton":
-			name := drawNameField(ui, ctx)
-			if name == "" {
-				go profileMenu(ui)
-				continue
+			name := drawNameField(app, ctx)
+			if name != "" {
+				app.updateProfile(func(profile *Profile) { profile.Nick = name })
 			}
-			DefaultGameInitData.Nick = name
-			go profileMenu(ui)
-
+			app.Nav.Replace(ctx, profileScreen())
+			return
 		case "editDescButton":
-			desc := drawDescField(ui, ctx)
-			if desc == "" {
-				go profileMenu(ui)
-				continue
+			desc := drawDescField(app, ctx)
+			if desc != "" {
+				app.updateProfile(func(profile *Profile) { profile.Desc = desc })
 			}
-			DefaultGameInitData.Desc = desc
-			go profileMenu(ui)
+			app.Nav.Replace(ctx, profileScreen())
+			return
 		case "randomBoardButton":
-			DefaultGameInitData.Coords = generateRandomBoard()
-			go profileMenu(ui)
+			app.updateProfile(func(profile *Profile) { profile.Coords = generateRandomBoard() })
+			app.Nav.Replace(ctx, profileScreen())
+			return
+		case "historyButton":
+			app.Nav.Push(ctx, historyScreen())
+			return
+		case "settingsButton":
+			app.Nav.Push(ctx, gameSettingsScreen())
+			return
+		case "themeButton":
+			// The theme is used by every screen drawn from now on, this one included
+			name := nextInCycle(themeNames, app.Theme().Name)
+			app.updateProfile(func(profile *Profile) { profile.Theme = name })
+			app.SetTheme(name)
+			app.Nav.Replace(ctx, profileScreen())
+			return
+		case "languageButton":
+			language := nextInCycle(languages, app.Language())
+			app.updateProfile(func(profile *Profile) { profile.Language = language })
+			app.SetLanguage(language)
+			app.Nav.Replace(ctx, profileScreen())
+			return
+		}
+		if ctx.Err() != nil {
+			return
 		}
 	}
 }
-func botMenu(ui *gui.GUI) {
-	botUi := BotElements(ui)
 
-	ctx := context.Background()
+func botScreen() Screen {
+	return Screen{Name: "bot", Enter: botMenu, Reflow: true}
+}
+
+func botMenu(ctx context.Context, app *App) {
+	botUi := BotElements(app)
 
 	for {
-		clicked := botUi.ButtonArea.Listen(ctx)
+		clicked := app.ListenArea(ctx, botUi.ButtonArea)
 		switch clicked {
 		case "returnButton":
-			go MainMenu(ui)
+			app.Nav.Pop(ctx)
 			return
 		case "wpBotButton":
-			gameData := GameInitData{
-				TargetNick: "",
-				Wpbot:      true,
+			gameData := app.GameData()
+			gameData.Wpbot = true
+			if err := StartGame(ctx, app, gameData); err != nil {
+				statusMessage(app, layout.Message(), app.T("error.startGame", err), app.Theme().Error)
+				continue
 			}
-			StartGame(ui, gameData)
+			return
 		case "bomBotButton":
-			bomBotInit(ui, DefaultGameInitData)
+			bomBotInit(ctx, app, app.GameData())
+			return
+		case "spectateButton":
+			app.Nav.Push(ctx, spectatorScreen())
+			return
+		}
+		if ctx.Err() != nil {
 			return
 		}
 	}
 }
 
-var isWaitingForChallenger bool
+// lobbySession is our place in the lobby. It's shared by every entry of the lobby screen,
+// so a resize doesn't restart the timer or the wait for a challenger.
+type lobbySession struct {
+	playerToken string
+	gameData    GameInitData
+
+	mu       sync.Mutex
+	deadline time.Time     // when the server drops us from the lobby
+	wait     time.Duration // how long the server keeps us without a refresh, 0 until its timer was seen
+
+	stop    context.CancelFunc // stops the wait for a challenger
+	done    chan struct{}      // closed when the wait is over
+	started bool               // the game started, set before done is closed
+}
 
-func pvpMenu(ui *gui.GUI, playerToken string, timerContext context.Context, cancelTimer context.CancelFunc, reset chan bool) {
-	ui.NewScreen("lobby")
-	ui.SetScreen("lobby")
-	// get lobby info
-	lobbyInfo, _, err := retryOnErrorWithPlayers(ui, func() ([]Player, string, error) {
-		return GetLobbyInfo()
-	})
-	if err != nil {
-		ui.Draw(gui.NewText(2, 0, "Error getting lobby info: "+err.Error(), errorText))
+// newLobbySession starts waiting for a challenger after we joined the lobby
+func newLobbySession(app *App, playerToken string, gameData GameInitData) *lobbySession {
+	ctx, stop := context.WithCancel(context.Background())
+	session := &lobbySession{
+		playerToken: playerToken,
+		gameData:    gameData,
+		deadline:    time.Now().Add(defaultLobbyWait),
+		stop:        stop,
+		done:        make(chan struct{}),
 	}
+	go func() {
+		defer close(session.done)
+		session.started = waitForStart(ctx, app, playerToken, gameData)
+	}()
+	return session
+}
 
-	lobbyUi := LobbyElements(ui, lobbyInfo)
+// Deadline returns when the server drops us from the lobby
+func (s *lobbySession) Deadline() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.deadline
+}
 
-	ctx := context.Background()
-	ui.Draw(gui.NewText(2, 9, "Click on an opponent to challenge him into a duel!", defaultText))
-	for {
-		//Listen what button was clicked
-		clicked := lobbyUi.ButtonArea.Listen(ctx)
-		switch clicked {
-		case "addYourselfButton":
-			// Adds player to lobby and starts the timer
-			gameData := GameInitData{
-				TargetNick: "",
-				Wpbot:      false,
+// refreshed moves the deadline after our place in the lobby was refreshed
+func (s *lobbySession) refreshed() {
+	s.mu.Lock()
+	s.deadline = time.Now().Add(s.lobbyWait())
+	s.mu.Unlock()
+}
+
+// serverTimer moves the deadline to the timer the server reports. The longest timer
+// seen is the lobby wait time of the server, the timer starts from it after a refresh.
+func (s *lobbySession) serverTimer(timer time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deadline = time.Now().Add(timer)
+	s.wait = max(s.wait, timer)
+}
+
+// lobbyWait returns how long the server keeps us in the lobby without a refresh, s.mu must be held
+func (s *lobbySession) lobbyWait() time.Duration {
+	if s.wait == 0 {
+		return defaultLobbyWait
+	}
+	return s.wait
+}
+
+// keepAliveInterval returns how often automatic refreshes are sent, so a couple of them
+// can fail before the server drops us
+func (s *lobbySession) keepAliveInterval() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lobbyWait() / 3
+}
+
+// lobbyScreen shows the lobby, session is nil if we didn't join it
+func lobbyScreen(session *lobbySession) Screen {
+	return Screen{Name: "lobby", Reflow: true, Enter: func(ctx context.Context, app *App) {
+		pvpMenu(ctx, app, session)
+	}}
+}
+
+// How often the lobby screen fetches the list of players
+const lobbyPollInterval = 2 * time.Second
+
+// How long players that just joined the lobby are highlighted
+const newcomerHighlight = 10 * time.Second
+
+func pvpMenu(ctx context.Context, app *App, session *lobbySession) {
+	lobbyUi := LobbyElements(app)
+
+	// Clicks from the action buttons and the player buttons end up on one channel,
+	// player buttons get a new area and listener whenever the list changes
+	clicks := make(chan string)
+	listen := func(ctx context.Context, area *gui.HandleArea) {
+		go func() {
+			for {
+				clicked := app.ListenArea(ctx, area)
+				select {
+				case clicks <- clicked:
+				case <-ctx.Done():
+					return
+				}
 			}
-			playerToken, err = retryOnError(ui, func() (string, error) {
-				return InitGame(gameData)
-			})
-			if err != nil {
-				ui.Draw(gui.NewText(1, 29, "Error initializing game: "+err.Error()+". Retrying...", errorText))
+		}()
+	}
+	listen(ctx, lobbyUi.ButtonArea)
+
+	updates := make(chan []Player)
+	refresh := make(chan bool, 1)
+	go pollLobby(ctx, app, updates, refresh)
+
+	if session != nil {
+		// The wait for a challenger goes on after a resize, it stops when the lobby is left
+		defer func() {
+			if !reflowing(ctx) {
+				session.stop()
+			}
+		}()
+		go lobbyTimer(ctx, app, lobbyUi.Status, session)
+		go func() {
+			select {
+			case <-session.done:
+			case <-ctx.Done():
+				return
 			}
-			timerContext, cancelTimer := context.WithCancel(context.Background())
-
-			go lobbyTimer(timerContext, reset, ui)
-			go waitForStart(ui, playerToken, gameData, cancelTimer)
-			go pvpMenu(ui, playerToken, timerContext, cancelTimer, reset)
-		case "resetLobbyTimerButton":
-			// If user is in lobby reset the timer
-			if playerToken == "" {
-				go pvpMenu(ui, playerToken, timerContext, cancelTimer, reset)
-				ui.Draw(gui.NewText(0, 0, "You are not in lobby", errorText))
+			if session.started {
+				notifyChallenge(ctx, app, lobbyUi.Status, session.playerToken)
+				app.Nav.Replace(ctx, gameScreen(session.playerToken, session.gameData))
 			} else {
-				reset <- true
-				RefreshLobby(playerToken)
-				go pvpMenu(ui, playerToken, timerContext, cancelTimer, reset)
-				ui.Draw(gui.NewText(2, 3, "Lobby timer reset", defaultText))
+				app.Nav.Replace(ctx, lobbyScreen(nil))
 			}
+		}()
+	}
 
-		case "returnButton":
-			// Stops the timer and returns to main menu
-			if cancelTimer != nil {
-				cancelTimer()
-			}
+	// Automatic keep-alive runs with its own context so it can be turned off
+	cancelKeepAlive := func() {}
+	defer func() { cancelKeepAlive() }()
+	startKeepAlive := func() {
+		if session != nil && app.Profile().KeepAlive {
+			keepAliveCtx, cancel := context.WithCancel(ctx)
+			cancelKeepAlive = cancel
+			go lobbyKeepAlive(keepAliveCtx, app, lobbyUi.Status, session)
+		}
+	}
+	startKeepAlive()
 
-			go MainMenu(ui)
+	app.Draw(gui.NewText(lobbyUi.Players.X, lobbyUi.Players.Y-2, app.T("lobby.hint"), app.Theme().Text))
+
+	var lobbyInfo []Player
+	var playersUi *LobbyUI
+	cancelPlayers := func() {}
+	defer func() { cancelPlayers() }()
+	tracker := newLobbyTracker()
+	shown := ""
+	for {
+		select {
+		case <-ctx.Done():
 			return
-		case "refreshButton":
-			// Refreshes the lobby
-			go pvpMenu(ui, playerToken, timerContext, cancelTimer, reset)
-		default:
-			// If player is not in lobby and clicked on a player, challenge him
-			if !isWaitingForChallenger {
+		case players := <-updates:
+			newcomers := tracker.update(players, time.Now())
+			// Only redraw when players, their status or highlights changed
+			if key := fmt.Sprint(players, newcomers); key != shown {
+				shown = key
+				lobbyInfo = players
+				cancelPlayers()
+				if playersUi != nil {
+					for _, drawable := range playersUi.Drawable {
+						app.Remove(drawable)
+					}
+				}
+				playersUi = LobbyPlayerElements(app, lobbyUi.Players, players, newcomers)
+				playersCtx, cancel := context.WithCancel(ctx)
+				cancelPlayers = cancel
+				listen(playersCtx, playersUi.ButtonArea)
+			}
+		case clicked := <-clicks:
+			switch clicked {
+			case "addYourselfButton":
+				// Adds player to lobby and starts the timer
+				playerToken, err := retryOnError(func() (string, error) {
+					return app.API.InitGame(app.GameData())
+				})
+				if err != nil {
+					slog.Error("starting a game", "err", err)
+					continue
+				}
+				app.Nav.Replace(ctx, lobbyScreen(newLobbySession(app, playerToken, app.GameData())))
+				return
+			case "resetLobbyTimerButton":
+				// If user is in lobby reset the timer
+				if session == nil {
+					statusMessage(app, lobbyUi.Status, app.T("lobby.notIn"), app.Theme().Error)
+				} else {
+					if err := app.API.RefreshLobby(session.playerToken); err != nil {
+						statusMessage(app, lobbyUi.Status, app.T("error.refreshLobby", err), app.Theme().Error)
+						continue
+					}
+					session.refreshed()
+					statusMessage(app, lobbyUi.Status, app.T("lobby.timerReset"), app.Theme().Text)
+				}
+			case "keepAliveButton":
+				on := !app.Profile().KeepAlive
+				app.updateProfile(func(profile *Profile) { profile.KeepAlive = on })
+				cancelKeepAlive()
+				cancelKeepAlive = func() {}
+				startKeepAlive()
+				// The area keeps the old button, the new one has the same position and size
+				x, y := lobbyUi.KeepAliveButton.Position()
+				app.Remove(lobbyUi.KeepAliveButton)
+				lobbyUi.KeepAliveButton = KeepAliveButton(app, x, y, on)
+				app.Draw(lobbyUi.KeepAliveButton)
+			case "returnButton":
+				app.Nav.Pop(ctx)
+				return
+			case "refreshButton":
+				select {
+				case refresh <- true:
+				default:
+				}
+			default:
+				// If player is not in lobby and clicked on a player, challenge him
+				if app.WaitingForChallenger() {
+					statusMessage(app, lobbyUi.Status, app.T("lobby.alreadyWaiting"), app.Theme().Error)
+					continue
+				}
 				for _, player := range lobbyInfo {
-					if player.Nick == clicked {
-						if DefaultGameInitData.Nick == player.Nick {
-							ui.Draw(gui.NewText(2, 2, "You can't duel yourself...", errorText))
-							continue
-						}
-
-						DefaultGameInitData.TargetNick = player.Nick
-						DefaultGameInitData.Wpbot = false
-						StartGame(ui, DefaultGameInitData)
-
+					if player.Nick != clicked {
+						continue
+					}
+					if app.Profile().Nick == player.Nick {
+						statusMessage(app, lobbyUi.Status, app.T("lobby.selfDuel"), app.Theme().Error)
 						break
 					}
+					// Compare with the player first, the challenge is sent from there
+					app.Nav.Push(ctx, compareScreen(player.Nick, true))
+					return
 				}
+			}
+		}
+	}
+}
+
+// pollLobby sends the list of players every lobbyPollInterval or right away when asked on refresh
+func pollLobby(ctx context.Context, app *App, updates chan<- []Player, refresh <-chan bool) {
+	ticker := time.NewTicker(lobbyPollInterval)
+	defer ticker.Stop()
+
+	for {
+		players, _, err := app.API.GetLobbyInfo()
+		if err != nil {
+			statusMessage(app, layout.Message(), app.T("error.lobbyInfo", err), app.Theme().Error)
+		} else {
+			select {
+			case updates <- players:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-refresh:
+		}
+	}
+}
+
+// lobbyTracker remembers when players joined the lobby, players that were
+// already there when the screen was opened are not newcomers
+type lobbyTracker struct {
+	firstSeen map[string]time.Time
+	started   bool
+}
+
+func newLobbyTracker() *lobbyTracker {
+	return &lobbyTracker{firstSeen: make(map[string]time.Time)}
+}
+
+// update returns the players that joined less than newcomerHighlight ago
+func (t *lobbyTracker) update(players []Player, now time.Time) map[string]bool {
+	present := make(map[string]bool)
+	for _, player := range players {
+		present[player.Nick] = true
+		if _, seen := t.firstSeen[player.Nick]; !seen {
+			if t.started {
+				t.firstSeen[player.Nick] = now
 			} else {
-				ui.Draw(gui.NewText(2, 2, "You are already waiting for a challenger...", errorText))
+				t.firstSeen[player.Nick] = time.Time{}
 			}
 		}
 	}
+	t.started = true
+
+	newcomers := make(map[string]bool)
+	for nick, seen := range t.firstSeen {
+		if !present[nick] {
+			// Players that leave and come back are new again
+			delete(t.firstSeen, nick)
+			continue
+		}
+		if !seen.IsZero() && now.Sub(seen) < newcomerHighlight {
+			newcomers[nick] = true
+		}
+	}
+	return newcomers
+}
+
+// notifyChallenge flashes the name of the player that challenged us in the lobby status before the game screen opens
+func notifyChallenge(ctx context.Context, app *App, status layout.Rect, playerToken string) {
+	opponent := app.T("lobby.someone")
+	if gameStatus, err := app.API.fetchGameStatus(ctx, playerToken); err == nil && gameStatus.Opponent != "" {
+		opponent = gameStatus.Opponent
+	}
+	text := app.T("lobby.challenged", opponent)
+
+	for i := 0; i < 6; i++ {
+		flash := app.Theme().HighlightText
+		if i%2 == 1 {
+			flash = app.Theme().Error
+		}
+		statusMessage(app, status, text, flash)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(300 * time.Millisecond):
+		}
+	}
 }
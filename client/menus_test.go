@@ -0,0 +1,27 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLobbySessionLearnsServerWait(t *testing.T) {
+	session := &lobbySession{}
+	if got, want := session.keepAliveInterval(), defaultLobbyWait/3; got != want {
+		t.Errorf("keepAliveInterval() before the server timer = %v, want %v", got, want)
+	}
+
+	session.serverTimer(60 * time.Second)
+	session.serverTimer(42 * time.Second)
+	if got, want := session.keepAliveInterval(), 20*time.Second; got != want {
+		t.Errorf("keepAliveInterval() = %v, want %v", got, want)
+	}
+	if until := time.Until(session.Deadline()); until > 42*time.Second || until < 40*time.Second {
+		t.Errorf("deadline in %v, want the last server timer", until)
+	}
+
+	session.refreshed()
+	if until := time.Until(session.Deadline()); until < 58*time.Second {
+		t.Errorf("deadline after a refresh in %v, want the whole lobby wait", until)
+	}
+}
@@ -0,0 +1,255 @@
+package client
+
+var messagesEN = map[string]string{
+	// Shared by many screens
+	"common.return": "Return",
+	"common.save":   "Save",
+	"common.cancel": "Cancel",
+	"common.clear":  "Clear",
+	"common.search": "Search",
+	"common.prev":   "Prev",
+	"common.next":   "Next",
+	"common.exit":   "Exit",
+
+	// Results of shots
+	"shot.hit":  "hit",
+	"shot.miss": "miss",
+	"shot.sunk": "sunk",
+
+	// Main menu
+	"menu.title":      "Bombowe Statki",
+	"menu.hallOfFame": "Hall of Fame",
+	"menu.pvp":        "PvP",
+	"menu.bot":        "Bot",
+	"menu.profile":    "Profile",
+	"menu.replays":    "Replays",
+	"menu.quick":      "Quick",
+	"menu.ranking":    "Ranking",
+
+	// Profile
+	"profile.title":       "Edit your profile",
+	"profile.currentName": "Current username: %s",
+	"profile.currentDesc": "Current description: %s",
+	"profile.editName":    "Edit Name",
+	"profile.editDesc":    "Edit Description",
+	"profile.editBoard":   "Edit Board Layout",
+	"profile.randomBoard": "Get Random Board",
+	"profile.history":     "Match History",
+	"profile.theme":       "Theme: %s",
+	"profile.language":    "Language: English",
+	"profile.board":       "Your current board layout",
+	"profile.enterName":   "Enter your new username here",
+	"profile.enterDesc":   "Enter your new description here",
+
+	// Player stats
+	"stats.header":  "Current Player Stats:",
+	"stats.nick":    "Player Nick: %s",
+	"stats.games":   "Games Played: %d",
+	"stats.points":  "Points: %d",
+	"stats.rank":    "Rank: %d",
+	"stats.wins":    "Wins: %d",
+	"stats.losses":  "Losses: %d",
+	"stats.winRate": "Win rate: %.1f%%",
+
+	// Lobby
+	"lobby.title":           "Lobby",
+	"lobby.refresh":         "Refresh",
+	"lobby.join":            "Join lobby",
+	"lobby.resetTimer":      "Reset Timer",
+	"lobby.keepAliveOn":     "Keep-alive: on",
+	"lobby.keepAliveOff":    "Keep-alive: off",
+	"lobby.empty":           "Lobby is empty",
+	"lobby.newcomer":        "%s (new)",
+	"lobby.hint":            "Click on an opponent to compare with him and challenge him into a duel!",
+	"lobby.notIn":           "You are not in lobby",
+	"lobby.timerReset":      "Lobby timer reset",
+	"lobby.alreadyWaiting":  "You are already waiting for a challenger...",
+	"lobby.selfDuel":        "You can't duel yourself...",
+	"lobby.someone":         "Someone",
+	"lobby.challenged":      "%s challenged you! Starting the game...",
+	"lobby.remaining":       "Time remaining: %d seconds (%s)",
+	"lobby.estimated":       "estimated",
+	"lobby.server":          "server",
+	"lobby.keepAliveFailed": "Keep-alive failed, retrying in %v: %v",
+	"lobby.refreshed":       "Lobby timer refreshed at %s",
+
+	// Bots
+	"bot.title":        "Pick a bot you want to fight against!",
+	"bot.watch":        "Watch",
+	"bombot.desc":      "Guarantees an explosive game!",
+	"bombot.surrender": "Error calculating possible ship locations. Surrendering game...",
+	"bot.wpBot":        "wpBot",
+	"bot.bomBot":       "bomBot",
+
+	// Ship placement editor
+	"editor.title":         "Ship placement editor",
+	"editor.help":          "Pick a ship, click the board to preview, click again to drop",
+	"editor.size":          "Size %d",
+	"editor.rotate":        "Rotate",
+	"editor.drop":          "Drop",
+	"editor.undo":          "Undo",
+	"editor.redo":          "Redo",
+	"editor.autoFill":      "Auto-fill",
+	"editor.cantDrop":      "Can't drop here: %v",
+	"editor.pickedUp":      "Picked up ship of size %d",
+	"editor.allPlaced":     "All ships of size %d are already placed",
+	"editor.pickFirst":     "Pick a ship first",
+	"editor.nothingToUndo": "Nothing to undo",
+	"editor.nothingToRedo": "Nothing to redo",
+	"editor.placeAll":      "Place all ships first, %d left",
+	"editor.saved":         "New ship layout saved, returning to profile...",
+	"editor.status":        "Placed %d/%d ships",
+	"editor.holding":       ", holding ship of size %d at %s",
+	"editor.left":          "%d left",
+	"editor.noShipsLeft":   "No ships of size %d left to place",
+	"editor.offBoard":      "Ship does not fit on the board",
+	"editor.touching":      "Ship overlaps or touches another ship",
+	"editor.noRoom":        "Remaining ships do not fit, move or remove some ships",
+
+	// Game
+	"game.leaving":         "Leaving game...",
+	"game.alreadyFired":    "You have already fired at this coordinate",
+	"game.inferredEmpty":   "No ship can be at %s",
+	"game.accuracy":        "Shot accuracy: %.2f%%",
+	"game.accuracyUnknown": "Shot accuracy: N/A",
+	"game.timer":           "Timer: %.0f",
+	"game.userNick":        "User Nick: %s",
+	"game.opponentNick":    "Opponent Nick: %s",
+	"game.win":             "Congratulations You Win",
+	"game.playerDiverged":  "Our board differed from the server at %s, redrawn",
+	"game.lose":            "Unfortunately You Lose",
+
+	// Game settings
+	"settings.title":      "Game settings",
+	"settings.help":       "Warnings ring as our turn runs out, auto-fire shoots just before it ends, ranked play turns hints off",
+	"settings.on":         "on",
+	"settings.off":        "off",
+	"settings.none":       "none",
+	"settings.warnings":   "Warn at: %s s",
+	"settings.bell":       "Bell: %s",
+	"settings.autoFire":   "Auto-fire: %s",
+	"settings.autoFireAt": "Auto-fire at: %d s left",
+	"settings.strategy":   "Auto-fire strategy: %s",
+	"settings.ranked":     "Ranked, no hints: %s",
+	"settings.heatmap":    "Hint heatmap: %s",
+	"game.hintButton":     "Hint",
+	"game.hint":           "Hint: fire at %s",
+	"game.noHint":         "No hint, nothing left to shoot at",
+	"profile.settings":    "Game settings",
+	"game.hurry":          "HURRY! %d seconds left",
+	"game.autoFired":      "Auto-fire shot at %s",
+
+	// Resuming a game left in progress
+	"resume.title":   "A game against %s is still in progress, we fired %d shots",
+	"resume.help":    "Resume the game or abandon it, abandoning counts as a loss",
+	"resume.resume":  "Resume game",
+	"resume.abandon": "Abandon game",
+	"resume.error":   "Can't resume the game: %v",
+	"resume.ended":   "The game has already ended",
+
+	// Status panel of the game
+	"panel.ourTurn":      ">>> YOUR TURN - FIRE! <<<",
+	"panel.opponentTurn": "Opponent's turn",
+	"panel.ended":        "Game over",
+	"panel.ourFleet":     "Our ships afloat: %s",
+	"panel.enemyFleet":   "Enemy ships afloat: %s",
+	"panel.received":     "Shots received: %d, hits: %d",
+	"panel.fired":        "Shots fired: %d, hits: %d",
+	"panel.streak":       "Hit streak: %d (best %d)",
+
+	// Replays and history
+	"replays.title":           "Recorded games",
+	"replays.empty":           "No recorded games yet, play a game first",
+	"replays.unfinished":      "unfinished",
+	"replays.entry":           "%s vs %s (%s, %s)",
+	"replay.first":            "First",
+	"replay.play":             "Play",
+	"replay.last":             "Last",
+	"replay.slower":           "Slower",
+	"replay.faster":           "Faster",
+	"replay.title":            "Replay: %s vs %s (%s)",
+	"replay.result":           "Result: %s",
+	"replay.autoPlay":         "Auto-play: %s, delay: %v",
+	"replay.move":             "Move %d/%d",
+	"replay.moveShot":         "Move %d/%d: %s fired at %s - %s",
+	"replay.sunk":             "Sunk ship of size %d: %s",
+	"replay.yourAccuracy":     "Your accuracy: %s",
+	"replay.opponentAccuracy": "Opponent accuracy: %s",
+	"history.title":           "Match history",
+	"history.date":            "Date",
+	"history.opponent":        "Opponent",
+	"history.mode":            "Mode",
+	"history.result":          "Result",
+	"history.shots":           "Shots",
+	"history.accuracy":        "Accuracy",
+	"history.duration":        "Duration",
+	"history.layout":          "Layout",
+	"history.winRate":         "Win rate: %s",
+	"history.avgShots":        "Average shots to win: %.1f",
+	"history.byMode":          "By mode:",
+	"history.byOpponent":      "By opponent:",
+	"history.byLayout":        "By layout:",
+	"history.heatmap":         "Where opponents hit us most:",
+
+	// Quick match
+	"quick.title":         "Quick match",
+	"quick.help":          "Challenges the waiting player with points closest to yours",
+	"quick.wait":          "Wait: %ds",
+	"quick.fallback":      "Then: %s",
+	"quick.excludeOn":     "Skip recent losses: on",
+	"quick.excludeOff":    "Skip recent losses: off",
+	"quick.stop":          "Stop",
+	"quick.stopFirst":     "Stop the search to change settings",
+	"quick.stopped":       "Search stopped",
+	"quick.challenging":   "Challenging %s...",
+	"quick.searching":     "Searching for an opponent, %ds left...",
+	"quick.fallbackBot":   "No opponent found, starting a game with wpBot",
+	"quick.fallbackLobby": "No opponent found, joining the lobby",
+
+	// Leaderboard, sort keys are names from leaderboardSorts
+	"leaderboard.title":    "Leaderboard",
+	"leaderboard.search":   "Nick contains: %s",
+	"leaderboard.minGames": "Min games: %d",
+	"leaderboard.nick":     "Nick",
+	"leaderboard.empty":    "No players match",
+	"leaderboard.page":     "Page %d/%d, click a player to see details",
+	"leaderboard.me":       "Me",
+	"sort.Rank":            "Rank",
+	"sort.Points":          "Points",
+	"sort.Wins":            "Wins",
+	"sort.Games":           "Games",
+	"sort.Win %":           "Win %",
+	"player.title":         "Player %s",
+	"compare.title":        "You vs %s",
+	"compare.challenge":    "Challenge",
+	"compare.winRate":      "Win rate",
+	"compare.headToHead":   "Head to head: %d wins, %d losses, %d unfinished",
+	"compare.noGames":      "No recorded games against %s",
+	"compare.recent":       "Recent results:",
+	"compare.match":        "%s  %-10s %3d shots, accuracy %s, %v",
+
+	// Spectator
+	"spectator.pause":    "Pause",
+	"spectator.newGame":  "New game",
+	"spectator.strategy": "%d: %s",
+	"spectator.gaveUp":   "Player %d gave up",
+	"spectator.shot":     "Player %d fired at %s - %s",
+	"spectator.player":   "Player %d (%s): %d shots, accuracy %s, %d wins",
+	"spectator.won":      "Player %d won! Next game in %v",
+	"spectator.games":    "Games: %d, auto-play: %s, delay: %v",
+
+	// Errors
+	"error.lobbyInfo":    "Error getting lobby info: %v",
+	"error.gameStatus":   "Error getting game status: %v",
+	"error.response":     "Error parsing response: %v",
+	"error.playerStats":  "Error getting player stats: %v",
+	"error.leaveGame":    "Error leaving game: %v",
+	"error.startGame":    "Error starting the game: %v",
+	"error.joinLobby":    "Error joining the lobby: %v",
+	"error.refreshLobby": "Error resetting the lobby timer: %v",
+	"error.saveProfile":  "Error saving profile: %v",
+	"error.history":      "Error loading match history: %v",
+	"error.stats":        "Error getting stats: %v",
+	"error.fire":         "Error firing: %v",
+	"error.replays":      "Error listing recorded games: %v",
+}
@@ -0,0 +1,255 @@
+package client
+
+var messagesPL = map[string]string{
+	// Shared by many screens
+	"common.return": "Powrót",
+	"common.save":   "Zapisz",
+	"common.cancel": "Anuluj",
+	"common.clear":  "Wyczyść",
+	"common.search": "Szukaj",
+	"common.prev":   "Wstecz",
+	"common.next":   "Dalej",
+	"common.exit":   "Wyjdź",
+
+	// Results of shots
+	"shot.hit":  "trafiony",
+	"shot.miss": "pudło",
+	"shot.sunk": "zatopiony",
+
+	// Main menu
+	"menu.title":      "Bombowe Statki",
+	"menu.hallOfFame": "Galeria sław",
+	"menu.pvp":        "PvP",
+	"menu.bot":        "Bot",
+	"menu.profile":    "Profil",
+	"menu.replays":    "Powtórki",
+	"menu.quick":      "Szybka",
+	"menu.ranking":    "Ranking",
+
+	// Profile
+	"profile.title":       "Edytuj swój profil",
+	"profile.currentName": "Obecny nick: %s",
+	"profile.currentDesc": "Obecny opis: %s",
+	"profile.editName":    "Zmień nick",
+	"profile.editDesc":    "Zmień opis",
+	"profile.editBoard":   "Edytuj planszę",
+	"profile.randomBoard": "Losowa plansza",
+	"profile.history":     "Historia meczów",
+	"profile.theme":       "Motyw: %s",
+	"profile.language":    "Język: polski",
+	"profile.board":       "Twoje obecne ustawienie statków",
+	"profile.enterName":   "Wpisz tutaj nowy nick",
+	"profile.enterDesc":   "Wpisz tutaj nowy opis",
+
+	// Player stats
+	"stats.header":  "Statystyki gracza:",
+	"stats.nick":    "Nick gracza: %s",
+	"stats.games":   "Rozegrane gry: %d",
+	"stats.points":  "Punkty: %d",
+	"stats.rank":    "Miejsce: %d",
+	"stats.wins":    "Wygrane: %d",
+	"stats.losses":  "Przegrane: %d",
+	"stats.winRate": "Procent wygranych: %.1f%%",
+
+	// Lobby
+	"lobby.title":           "Poczekalnia",
+	"lobby.refresh":         "Odśwież",
+	"lobby.join":            "Dołącz",
+	"lobby.resetTimer":      "Reset czasu",
+	"lobby.keepAliveOn":     "Podtrzymuj: tak",
+	"lobby.keepAliveOff":    "Podtrzymuj: nie",
+	"lobby.empty":           "Poczekalnia jest pusta",
+	"lobby.newcomer":        "%s (nowy)",
+	"lobby.hint":            "Kliknij przeciwnika, aby porównać się z nim i wyzwać go na pojedynek!",
+	"lobby.notIn":           "Nie jesteś w poczekalni",
+	"lobby.timerReset":      "Licznik poczekalni zresetowany",
+	"lobby.alreadyWaiting":  "Już czekasz na przeciwnika...",
+	"lobby.selfDuel":        "Nie możesz walczyć sam ze sobą...",
+	"lobby.someone":         "Ktoś",
+	"lobby.challenged":      "%s wyzwał cię na pojedynek! Rozpoczynam grę...",
+	"lobby.remaining":       "Pozostały czas: %d s (%s)",
+	"lobby.estimated":       "szacowany",
+	"lobby.server":          "z serwera",
+	"lobby.keepAliveFailed": "Podtrzymanie nie powiodło się, ponowienie za %v: %v",
+	"lobby.refreshed":       "Licznik poczekalni odświeżony o %s",
+
+	// Bots
+	"bot.title":        "Wybierz bota, z którym chcesz walczyć!",
+	"bot.watch":        "Oglądaj",
+	"bombot.desc":      "Zapewnia wybuchową rozgrywkę!",
+	"bombot.surrender": "Błąd wyznaczania możliwych pozycji statków. Poddaję grę...",
+	"bot.wpBot":        "wpBot",
+	"bot.bomBot":       "bomBot",
+
+	// Ship placement editor
+	"editor.title":         "Edytor ustawienia statków",
+	"editor.help":          "Wybierz statek, kliknij planszę, aby zobaczyć podgląd, kliknij ponownie, aby go postawić",
+	"editor.size":          "Rozmiar %d",
+	"editor.rotate":        "Obróć",
+	"editor.drop":          "Postaw",
+	"editor.undo":          "Cofnij",
+	"editor.redo":          "Ponów",
+	"editor.autoFill":      "Uzupełnij",
+	"editor.cantDrop":      "Nie można tu postawić: %v",
+	"editor.pickedUp":      "Podniesiono statek o rozmiarze %d",
+	"editor.allPlaced":     "Wszystkie statki o rozmiarze %d są już postawione",
+	"editor.pickFirst":     "Najpierw wybierz statek",
+	"editor.nothingToUndo": "Nie ma czego cofnąć",
+	"editor.nothingToRedo": "Nie ma czego ponowić",
+	"editor.placeAll":      "Najpierw postaw wszystkie statki, zostało %d",
+	"editor.saved":         "Zapisano nowe ustawienie statków, powrót do profilu...",
+	"editor.status":        "Postawiono %d/%d statków",
+	"editor.holding":       ", trzymasz statek o rozmiarze %d na %s",
+	"editor.left":          "zostało %d",
+	"editor.noShipsLeft":   "Nie ma już statków o rozmiarze %d do postawienia",
+	"editor.offBoard":      "Statek nie mieści się na planszy",
+	"editor.touching":      "Statek nachodzi na inny statek albo go dotyka",
+	"editor.noRoom":        "Pozostałe statki się nie mieszczą, przesuń albo usuń któreś z postawionych",
+
+	// Game
+	"game.leaving":         "Opuszczanie gry...",
+	"game.alreadyFired":    "Już strzelałeś w to pole",
+	"game.inferredEmpty":   "Na %s nie może być statku",
+	"game.accuracy":        "Celność: %.2f%%",
+	"game.accuracyUnknown": "Celność: brak",
+	"game.timer":           "Czas: %.0f",
+	"game.userNick":        "Twój nick: %s",
+	"game.opponentNick":    "Nick przeciwnika: %s",
+	"game.win":             "Gratulacje, wygrałeś",
+	"game.playerDiverged":  "Nasza plansza różniła się od serwera w %s, narysowano ją ponownie",
+	"game.lose":            "Niestety przegrałeś",
+
+	// Game settings
+	"settings.title":      "Ustawienia gry",
+	"settings.help":       "Ostrzeżenia dzwonią pod koniec tury, auto-strzał strzela przed jej końcem, ranking wyłącza podpowiedzi",
+	"settings.on":         "tak",
+	"settings.off":        "nie",
+	"settings.none":       "brak",
+	"settings.warnings":   "Ostrzegaj przy: %s s",
+	"settings.bell":       "Dzwonek: %s",
+	"settings.autoFire":   "Auto-strzał: %s",
+	"settings.autoFireAt": "Auto-strzał przy: %d s",
+	"settings.strategy":   "Strategia auto-strzału: %s",
+	"settings.ranked":     "Rankingowa: %s",
+	"settings.heatmap":    "Mapa ciepła podpowiedzi: %s",
+	"game.hintButton":     "Podpowiedź",
+	"game.hint":           "Podpowiedź: strzel w %s",
+	"game.noHint":         "Brak podpowiedzi, nie ma już w co strzelać",
+	"profile.settings":    "Ustawienia gry",
+	"game.hurry":          "POŚPIESZ SIĘ! Zostało %d s",
+	"game.autoFired":      "Auto-strzał w %s",
+
+	// Resuming a game left in progress
+	"resume.title":   "Gra z %s wciąż trwa, oddaliśmy %d strzałów",
+	"resume.help":    "Wznów grę albo ją porzuć, porzucenie liczy się jako przegrana",
+	"resume.resume":  "Wznów grę",
+	"resume.abandon": "Porzuć grę",
+	"resume.error":   "Nie można wznowić gry: %v",
+	"resume.ended":   "Gra już się skończyła",
+
+	// Status panel of the game
+	"panel.ourTurn":      ">>> TWÓJ RUCH - STRZELAJ! <<<",
+	"panel.opponentTurn": "Ruch przeciwnika",
+	"panel.ended":        "Koniec gry",
+	"panel.ourFleet":     "Nasze statki na wodzie: %s",
+	"panel.enemyFleet":   "Statki wroga na wodzie: %s",
+	"panel.received":     "Otrzymane strzały: %d, trafienia: %d",
+	"panel.fired":        "Oddane strzały: %d, trafienia: %d",
+	"panel.streak":       "Seria trafień: %d (najlepsza %d)",
+
+	// Replays and history
+	"replays.title":           "Nagrane gry",
+	"replays.empty":           "Brak nagranych gier, najpierw zagraj",
+	"replays.unfinished":      "niedokończona",
+	"replays.entry":           "%s vs %s (%s, %s)",
+	"replay.first":            "Początek",
+	"replay.play":             "Graj",
+	"replay.last":             "Koniec",
+	"replay.slower":           "Wolniej",
+	"replay.faster":           "Szybciej",
+	"replay.title":            "Powtórka: %s vs %s (%s)",
+	"replay.result":           "Wynik: %s",
+	"replay.autoPlay":         "Autoodtwarzanie: %s, opóźnienie: %v",
+	"replay.move":             "Ruch %d/%d",
+	"replay.moveShot":         "Ruch %d/%d: %s strzelił w %s - %s",
+	"replay.sunk":             "Zatopiony statek o rozmiarze %d: %s",
+	"replay.yourAccuracy":     "Twoja celność: %s",
+	"replay.opponentAccuracy": "Celność przeciwnika: %s",
+	"history.title":           "Historia meczów",
+	"history.date":            "Data",
+	"history.opponent":        "Przeciwnik",
+	"history.mode":            "Tryb",
+	"history.result":          "Wynik",
+	"history.shots":           "Strzały",
+	"history.accuracy":        "Celność",
+	"history.duration":        "Czas",
+	"history.layout":          "Układ",
+	"history.winRate":         "Procent wygranych: %s",
+	"history.avgShots":        "Średnio strzałów do wygranej: %.1f",
+	"history.byMode":          "Według trybu:",
+	"history.byOpponent":      "Według przeciwnika:",
+	"history.byLayout":        "Według układu:",
+	"history.heatmap":         "Gdzie przeciwnicy trafiają nas najczęściej:",
+
+	// Quick match
+	"quick.title":         "Szybki mecz",
+	"quick.help":          "Wyzywa czekającego gracza o liczbie punktów najbliższej twojej",
+	"quick.wait":          "Czekaj: %ds",
+	"quick.fallback":      "Potem: %s",
+	"quick.excludeOn":     "Pomijaj przegrane: tak",
+	"quick.excludeOff":    "Pomijaj przegrane: nie",
+	"quick.stop":          "Stop",
+	"quick.stopFirst":     "Zatrzymaj wyszukiwanie, aby zmienić ustawienia",
+	"quick.stopped":       "Wyszukiwanie zatrzymane",
+	"quick.challenging":   "Wyzywanie gracza %s...",
+	"quick.searching":     "Szukanie przeciwnika, zostało %ds...",
+	"quick.fallbackBot":   "Nie znaleziono przeciwnika, gra z wpBotem",
+	"quick.fallbackLobby": "Nie znaleziono przeciwnika, dołączanie do poczekalni",
+
+	// Leaderboard, sort keys are names from leaderboardSorts
+	"leaderboard.title":    "Ranking",
+	"leaderboard.search":   "Nick zawiera: %s",
+	"leaderboard.minGames": "Min. gier: %d",
+	"leaderboard.nick":     "Nick",
+	"leaderboard.empty":    "Brak pasujących graczy",
+	"leaderboard.page":     "Strona %d/%d, kliknij gracza, aby zobaczyć szczegóły",
+	"leaderboard.me":       "Ja",
+	"sort.Rank":            "Miejsce",
+	"sort.Points":          "Punkty",
+	"sort.Wins":            "Wygrane",
+	"sort.Games":           "Gry",
+	"sort.Win %":           "% wygr.",
+	"player.title":         "Gracz %s",
+	"compare.title":        "Ty vs %s",
+	"compare.challenge":    "Wyzwij",
+	"compare.winRate":      "% wygr.",
+	"compare.headToHead":   "Bilans: %d wygranych, %d przegranych, %d niedokończonych",
+	"compare.noGames":      "Brak nagranych gier z graczem %s",
+	"compare.recent":       "Ostatnie wyniki:",
+	"compare.match":        "%s  %-10s %3d strzałów, celność %s, %v",
+
+	// Spectator
+	"spectator.pause":    "Pauza",
+	"spectator.newGame":  "Nowa gra",
+	"spectator.strategy": "%d: %s",
+	"spectator.gaveUp":   "Gracz %d się poddał",
+	"spectator.shot":     "Gracz %d strzelił w %s - %s",
+	"spectator.player":   "Gracz %d (%s): %d strzałów, celność %s, %d wygranych",
+	"spectator.won":      "Gracz %d wygrał! Następna gra za %v",
+	"spectator.games":    "Gry: %d, autoodtwarzanie: %s, opóźnienie: %v",
+
+	// Errors
+	"error.lobbyInfo":    "Błąd pobierania poczekalni: %v",
+	"error.gameStatus":   "Błąd pobierania stanu gry: %v",
+	"error.response":     "Błąd odczytu odpowiedzi: %v",
+	"error.playerStats":  "Błąd pobierania statystyk gracza: %v",
+	"error.leaveGame":    "Błąd opuszczania gry: %v",
+	"error.startGame":    "Błąd rozpoczynania gry: %v",
+	"error.joinLobby":    "Błąd dołączania do poczekalni: %v",
+	"error.refreshLobby": "Błąd resetowania licznika poczekalni: %v",
+	"error.saveProfile":  "Błąd zapisywania profilu: %v",
+	"error.history":      "Błąd wczytywania historii meczów: %v",
+	"error.stats":        "Błąd pobierania statystyk: %v",
+	"error.fire":         "Błąd strzału: %v",
+	"error.replays":      "Błąd listowania nagranych gier: %v",
+}
@@ -0,0 +1,155 @@
+package client
+
+import (
+	"BomboweStatki/layout"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+// Screen is a single view of the app. Enter draws the screen and handles its input
+// until ctx is cancelled, which happens when the navigator leaves the screen.
+// Exit is optional and runs after Enter has returned.
+// Screens with Reflow are entered again when the terminal is resized, so their layout is computed anew.
+type Screen struct {
+	Name   string
+	Enter  func(ctx context.Context, app *App)
+	Exit   func()
+	Reflow bool
+}
+
+// errReflow is why a screen is cancelled when it's about to be entered again after a resize
+var errReflow = errors.New("screen reflowed")
+
+// reflowing reports whether the screen of ctx was left only to be entered again after a
+// resize, state the screen shares between its entries should be kept then
+func reflowing(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), errReflow)
+}
+
+type navAction int
+
+const (
+	navPush navAction = iota
+	navPop
+	navReplace
+	navReset
+)
+
+// How often the terminal size is checked for screens with Reflow
+const resizeCheckInterval = 500 * time.Millisecond
+
+type navRequest struct {
+	from   context.Context // context of the screen that asked for the change or derived from it
+	action navAction
+	screen Screen
+}
+
+// screenKey marks contexts of screens with the number of the screen
+type screenKey struct{}
+
+// Navigator keeps a stack of screens, only the screen on top is running.
+// Navigation methods take the context of the calling screen or one derived from it,
+// requests made by screens that were already left are ignored.
+type Navigator struct {
+	app      *App
+	requests chan navRequest
+	entered  int // number of screens entered so far
+}
+
+func newNavigator(app *App) *Navigator {
+	return &Navigator{app: app, requests: make(chan navRequest)}
+}
+
+// Push opens the screen on top of the current one
+func (n *Navigator) Push(ctx context.Context, screen Screen) {
+	n.navigate(navRequest{from: ctx, action: navPush, screen: screen})
+}
+
+// Pop goes back to the previous screen
+func (n *Navigator) Pop(ctx context.Context) {
+	n.navigate(navRequest{from: ctx, action: navPop})
+}
+
+// Replace swaps the current screen for another one, going back skips the replaced screen
+func (n *Navigator) Replace(ctx context.Context, screen Screen) {
+	n.navigate(navRequest{from: ctx, action: navReplace, screen: screen})
+}
+
+// Reset drops the whole stack and opens the screen as the only one
+func (n *Navigator) Reset(ctx context.Context, screen Screen) {
+	n.navigate(navRequest{from: ctx, action: navReset, screen: screen})
+}
+
+func (n *Navigator) navigate(req navRequest) {
+	select {
+	case n.requests <- req:
+	case <-req.from.Done():
+	}
+}
+
+// Run shows the root screen and handles navigation until the last screen is popped.
+// Before the next screen is entered the current one is cancelled and Run waits
+// for it to return, so its listeners never outlive it.
+func (n *Navigator) Run(root Screen) {
+	stack := []Screen{root}
+	for len(stack) > 0 {
+		screen := stack[len(stack)-1]
+		n.app.NewScreen(screen.Name)
+		n.app.SetScreen(screen.Name)
+		slog.Debug("entering screen", "name", screen.Name, "depth", len(stack))
+
+		n.entered++
+		id := n.entered
+		ctx, cancel := context.WithCancelCause(context.WithValue(context.Background(), screenKey{}, id))
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			screen.Enter(ctx, n.app)
+		}()
+
+		ticker := time.NewTicker(resizeCheckInterval)
+		var resized <-chan time.Time
+		if screen.Reflow {
+			resized = ticker.C
+		}
+		width, height := layout.Size()
+
+		var req navRequest
+		var cause error
+		for waiting := true; waiting; {
+			select {
+			case req = <-n.requests:
+				waiting = req.from.Value(screenKey{}) != id || req.from.Err() != nil
+			case <-done:
+				// A screen that returns on its own is left as if it was popped
+				req = navRequest{action: navPop}
+				waiting = false
+			case <-resized:
+				if w, h := layout.Size(); w != width || h != height {
+					req = navRequest{action: navReplace, screen: screen}
+					cause = errReflow
+					waiting = false
+				}
+			}
+		}
+		ticker.Stop()
+		cancel(cause)
+		<-done
+		if screen.Exit != nil {
+			screen.Exit()
+		}
+
+		switch req.action {
+		case navPush:
+			stack = append(stack, req.screen)
+		case navPop:
+			stack = stack[:len(stack)-1]
+		case navReplace:
+			stack[len(stack)-1] = req.screen
+		case navReset:
+			stack = []Screen{req.screen}
+		}
+	}
+}
@@ -2,13 +2,12 @@ package client
 
 import (
 	board "BomboweStatki/board"
+	"BomboweStatki/layout"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"log/slog"
 	"sort"
-	"strconv"
-	"sync"
 	"time"
 
 	gui "github.com/s25867/warships-gui/v2"
@@ -21,96 +20,72 @@ type GameStatusResponse struct {
 	OppShots       []string `json:"opp_shots"`
 	Opponent       string   `json:"opponent"`
 	ShouldFire     bool     `json:"should_fire"`
+	Timer          int      `json:"timer"`
 }
 
-func waitForStart(ui *gui.GUI, playerToken string, gameData GameInitData, cancel context.CancelFunc) {
-	isWaitingForChallenger = true
-	gameStarted := false
-	userInLobby := false
+// waitForStart polls the server until our game starts, it returns false if we are
+// neither in the lobby nor in a game anymore or the screen was left
+func waitForStart(ctx context.Context, app *App, playerToken string, gameData GameInitData) bool {
+	app.SetWaitingForChallenger(true)
+	defer app.SetWaitingForChallenger(false)
 
-	for !gameStarted || !userInLobby {
-		lobbyInfo, _, err := retryOnErrorWithPlayers(ui, func() ([]Player, string, error) {
-			return GetLobbyInfo()
+	for {
+		lobbyInfo, _, err := retryOnErrorWithPlayers(func() ([]Player, string, error) {
+			return app.API.GetLobbyInfo()
 		})
 		if err != nil {
-			ui.Draw(gui.NewText(2, 0, "Error getting lobby info: "+err.Error(), errorText))
+			statusMessage(app, layout.Message(), app.T("error.lobbyInfo", err), app.Theme().Error)
 		}
 
-		userInLobby = false
+		// Check if the player is in the lobby and waiting for a game
+		userInLobby := false
 		for _, player := range lobbyInfo {
-			// Check if the player is in the lobby and waiting for a game
-			if player.Nick == DefaultGameInitData.Nick {
+			if player.Nick == gameData.Nick {
 				userInLobby = true
-				if player.GameStatus == "waiting" {
-					gameStarted = false
-					time.Sleep(200 * time.Millisecond)
-					break
-				}
+				break
 			}
 		}
 		// If the player is not in the lobby, check if he is in a game
 		if !userInLobby {
-			gameStatusResponse, err := retryOnError(ui, func() (string, error) {
-				return GetGameStatus(playerToken)
+			gameStatusResponse, err := retryOnError(func() (string, error) {
+				return app.API.GetGameStatus(playerToken)
 			})
 			if err != nil {
-				ui.Draw(gui.NewText(2, 0, "Error getting game status: "+err.Error(), errorText))
+				statusMessage(app, layout.Message(), app.T("error.gameStatus", err), app.Theme().Error)
 			}
 
 			var gameStatus GameStatusResponse
 			err = json.Unmarshal([]byte(gameStatusResponse), &gameStatus)
 			if err != nil {
-				ui.Draw(gui.NewText(2, 0, "Error unmarshaling response: "+err.Error(), errorText))
+				statusMessage(app, layout.Message(), app.T("error.response", err), app.Theme().Error)
 			}
 
-			if gameStatus.GameStatus == "game_in_progress" { //if he is in a game, launch the board
-				cancel()
-				ui.NewScreen("game" + playerToken)
-				ui.SetScreen("game" + playerToken)
-				LaunchGameBoard(ui, playerToken, gameData)
-				isWaitingForChallenger = false
-				return
-			} else if gameStatus.GameStatus == "" { // if he is not in a game, return to lobby
-				cancel()
-				go pvpMenu(ui, "", nil, nil, make(chan bool))
-				isWaitingForChallenger = false
-				return
+			if gameStatus.GameStatus == "game_in_progress" {
+				return true
+			} else if gameStatus.GameStatus == "" {
+				return false
 			}
 		}
-		time.Sleep(200 * time.Millisecond)
-	}
-	isWaitingForChallenger = false
-}
 
-// Inits a board and launches editBoard
-func changeShipLayout(ui *gui.GUI) error {
-	ui.NewScreen("game")
-	ui.SetScreen("game")
-
-	playerShipCoordinates := DefaultGameInitData.Coords
-
-	playerStates, opponentStates, _, err := board.Config(playerShipCoordinates)
-	if err != nil {
-		ui.Draw(gui.NewText(1, 28, "Error launching the board: "+err.Error(), errorText))
-		return err
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(200 * time.Millisecond):
+		}
 	}
+}
 
-	_, opponentBoard, buttonArea := board.GuiInit(ui, playerStates, opponentStates)
-
-	newShipLayout := []string{}
-	shipTypes := []int{4, 3, 3, 2, 2, 2, 1, 1, 1, 1}
-	go editBoard(ui, opponentBoard, opponentStates, newShipLayout, shipTypes, buttonArea)
-
-	return errors.New("finished editing board")
+func editorScreen() Screen {
+	return Screen{Name: "editor", Enter: editBoard}
 }
 
 // printTopPlayers prints the top 10 players on the UI, split into two columns
-func printTopPlayers(ui *gui.GUI, x, y int) {
+func printTopPlayers(app *App, x, y int) {
 	// Sort players by points (descending order)
 	var players []PlayerStats
 	var err error
 	for i := 0; i < 10; i++ {
-		players, err = GetStats()
+		players, err = app.API.GetStats()
 		if err == nil {
 			break
 		}
@@ -118,13 +93,15 @@ func printTopPlayers(ui *gui.GUI, x, y int) {
 	}
 
 	if err != nil {
-		ui.Draw(gui.NewText(0, 0, "Error: "+err.Error(), errorText))
+		slog.Error("getting top players", "err", err)
 		return
 	}
 
 	sort.Slice(players, func(i, j int) bool {
 		return players[i].Points > players[j].Points
 	})
+	// The rest of the players is on the leaderboard screen
+	players = players[:min(len(players), 10)]
 
 	// Calculate the maximum name length
 	maxNameLength := 0
@@ -139,98 +116,179 @@ func printTopPlayers(ui *gui.GUI, x, y int) {
 
 	// Print players in two columns
 	for i, player := range players {
-		textConfig := gui.NewTextConfig()
-		textConfig.FgColor = gui.Yellow
-		textConfig.BgColor = gui.Black
 		column := i % 2
 		row := i / 2
 		columnX := x + (column * (columnWidth + 5)) // Adjust spacing between columns
-		ui.Draw(gui.NewText(columnX, y+row*6, fmt.Sprintf("Rank: %d", player.Rank), textConfig))
-		ui.Draw(gui.NewText(columnX, y+1+row*6, "Player Nick: "+player.Nick, textConfig))
-		ui.Draw(gui.NewText(columnX, y+2+row*6, "Games Played: "+strconv.Itoa(player.Games), textConfig))
-		ui.Draw(gui.NewText(columnX, y+3+row*6, "Points: "+strconv.Itoa(player.Points), textConfig))
-		ui.Draw(gui.NewText(columnX, y+4+row*6, "Wins: "+strconv.Itoa(player.Wins), textConfig))
+		app.Draw(gui.NewText(columnX, y+row*6, app.T("stats.rank", player.Rank), app.Theme().HighlightText))
+		app.Draw(gui.NewText(columnX, y+1+row*6, app.T("stats.nick", player.Nick), app.Theme().HighlightText))
+		app.Draw(gui.NewText(columnX, y+2+row*6, app.T("stats.games", player.Games), app.Theme().HighlightText))
+		app.Draw(gui.NewText(columnX, y+3+row*6, app.T("stats.points", player.Points), app.Theme().HighlightText))
+		app.Draw(gui.NewText(columnX, y+4+row*6, app.T("stats.wins", player.Wins), app.Theme().HighlightText))
 	}
 }
 
-// Start the game by collecting data and passing it to LaunchGameBoard
-func StartGame(ui *gui.GUI, gameData GameInitData) error {
-
-	playerToken, err := retryOnError(ui, func() (string, error) {
-		return InitGame(gameData)
+// StartGame creates the game on the server and opens the game screen in place of the current one
+func StartGame(ctx context.Context, app *App, gameData GameInitData) error {
+	playerToken, err := retryOnError(func() (string, error) {
+		return app.API.InitGame(gameData)
 	})
 	if err != nil {
-		ui.Draw(gui.NewText(1, 29, "Error initializing game: "+err.Error()+". Retrying in 2 seconds...", errorText))
+		slog.Error("starting a game", "err", err)
+		return err
 	}
 
-	ui.NewScreen("game" + playerToken)
-	ui.SetScreen("game" + playerToken)
+	app.Nav.Replace(ctx, gameScreen(playerToken, gameData))
+	return nil
+}
+
+// gameScreen waits for the game to start and shows the boards until it ends,
+// then it goes back to the main menu
+func gameScreen(playerToken string, gameData GameInitData) Screen {
+	return Screen{Name: "game" + playerToken, Enter: func(ctx context.Context, app *App) {
+		if !waitForStart(ctx, app, playerToken, gameData) {
+			app.Nav.Replace(ctx, lobbyScreen(nil))
+			return
+		}
 
-	go waitForStart(ui, playerToken, gameData, context.CancelFunc(func() {}))
+		if err := LaunchGameBoard(ctx, app, playerToken, gameData); err != nil {
+			slog.Error("playing the game", "err", err)
+		}
+		leaveGame(ctx, app)
+	}}
+}
+
+// leaveGame leaves the result of a game on screen for a moment and goes back to the main menu
+func leaveGame(ctx context.Context, app *App) {
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(5 * time.Second):
+	}
+	app.Nav.Reset(ctx, mainMenuScreen())
+}
+
+// LaunchGameBoard shows both boards and runs the game until it ends or ctx is cancelled
+func LaunchGameBoard(ctx context.Context, app *App, playerToken string, gameData GameInitData) error {
+	// Record the game to a file in the games directory
+	start := gameStartEvent(app, playerToken, gameData)
+	recorder, err := newGameRecorder(start)
+	if err != nil {
+		slog.Warn("recording the game", "err", err)
+	}
 
-	return errors.New("game ended")
+	session := &gameSession{Token: playerToken, Opponent: start.Opponent, Game: gameData, Record: recorder.path()}
+	return playSession(ctx, app, session, recorder)
 }
 
-func LaunchGameBoard(ui *gui.GUI, playerToken string, gameData GameInitData) error {
+// playSession runs the game of the session until it ends or ctx is cancelled. The session
+// is saved so the game can be resumed if the client exits, and removed when the game ends.
+func playSession(ctx context.Context, app *App, session *gameSession, recorder *gameRecorder) error {
+	// Anything that ends the game before its outcome is known is an error
+	reason := "error"
+	defer func() { recorder.close(reason) }()
+
 	// Configure the board
-	playerStates, opponentStates, shipStatus, err := board.Config(DefaultGameInitData.Coords)
+	playerStates, opponentStates, _, err := board.Config(session.Game.Coords)
 
 	if err != nil {
 		return fmt.Errorf("error launching the board: %v", err)
 	}
 
 	// Initialize the GUI for the board
-	playerBoard, opponentBoard, buttonArea := board.GuiInit(ui, playerStates, opponentStates)
+	playerBoard, opponentBoard, buttonArea := board.GuiInit(app, app.boardStyle(), playerStates, opponentStates)
 
-	dataCoords, err := GetBoardInfoWithRetry(playerToken)
+	dataCoords, err := app.API.GetBoardInfoWithRetry(session.Token)
 	if err != nil {
 		return fmt.Errorf("error getting board info: %v", err)
 	}
+	app.saveSession(session)
+
+	// Start operations on the player and opponent boards, displayGameStatus cancels them when the game ends
+	gameCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	panel := newGamePanel(app)
+	go displayGameStatus(gameCtx, session.Token, app, cancel, recorder, panel)
+	// Our board is drawn from the layout on the server and the shots of the opponent
+	boards := newBoardSync(dataCoords, playerStates)
+	go opponentBoardOperations(gameCtx, session, opponentBoard, app, buttonArea, boards, recorder, panel)
+	go playerBoardOperations(gameCtx, session.Token, playerBoard, app, boards, recorder, panel)
+
+	<-gameCtx.Done()
+	// The game is over unless we only left the screen, then it can still be resumed
+	if ctx.Err() != nil {
+		reason = ""
+		return nil
+	}
+	app.removeSession()
+	return nil
+}
 
-	// Start operations on the player and opponent boards
-	ctx, cancel := context.WithCancel(context.Background())
-	go displayGameStatus(ctx, playerToken, ui, cancel)
-	go opponentBoardOperations(ctx, playerToken, opponentBoard, opponentStates, ui, buttonArea)
+// gameStartEvent collects the metadata of a game that has just started,
+// the layout is the one the server accepted, it may differ from ours
+func gameStartEvent(app *App, playerToken string, gameData GameInitData) GameEvent {
+	start := GameEvent{
+		Nick:   gameData.Nick,
+		Desc:   gameData.Desc,
+		Coords: gameData.Coords,
+	}
+	if coords, err := app.API.GetBoardInfoWithRetry(playerToken); err == nil && len(coords) > 0 {
+		start.Coords = coords
+	} else if err != nil {
+		slog.Warn("getting the layout accepted by the server", "err", err)
+	}
 
-	go playerBoardOperations(ctx, playerToken, playerBoard, playerStates, ui, shipStatus, dataCoords)
+	gameStatusResponse, err := retryOnError(func() (string, error) {
+		return app.API.GetGameStatus(playerToken)
+	})
+	if err == nil {
+		var gameStatus GameStatusResponse
+		if json.Unmarshal([]byte(gameStatusResponse), &gameStatus) == nil {
+			start.Opponent = gameStatus.Opponent
+		}
+	}
+	start.OppDesc, _ = retryOnError(func() (string, error) {
+		return app.API.GetGameDescription(playerToken)
+	})
+	start.Mode = gameMode(gameData, start.Opponent)
 
-	return nil
+	return start
 }
 
-func printPlayerStats(ui *gui.GUI, nick string, x, y int) {
-	playerStats, err := retryOnErrorWithPlayerStats(ui, func() (PlayerStats, error) {
-		return GetPlayerStats(nick)
+func printPlayerStats(app *App, nick string, x, y int) {
+	playerStats, err := retryOnErrorWithPlayerStats(func() (PlayerStats, error) {
+		return app.API.GetPlayerStats(nick)
 	})
 	if err != nil {
-		ui.Draw(gui.NewText(2, 0, "Error getting player stats: "+err.Error(), errorText))
+		statusMessage(app, layout.Message(), app.T("error.playerStats", err), app.Theme().Error)
 		return
 	}
 
-	ui.Draw(gui.NewText(x, y, "Current Player Stats:", defaultText)) // Header
-	ui.Draw(gui.NewText(x, y+1, "Player Nick: "+playerStats.Nick, defaultText))
-	ui.Draw(gui.NewText(x, y+2, "Games Played: "+strconv.Itoa(playerStats.Games), defaultText))
-	ui.Draw(gui.NewText(x, y+3, "Points: "+strconv.Itoa(playerStats.Points), defaultText))
-	ui.Draw(gui.NewText(x, y+4, "Rank: "+strconv.Itoa(playerStats.Rank), defaultText))
-	ui.Draw(gui.NewText(x, y+5, "Wins: "+strconv.Itoa(playerStats.Wins), defaultText))
+	app.Draw(gui.NewText(x, y, app.T("stats.header"), app.Theme().Text)) // Header
+	app.Draw(gui.NewText(x, y+1, app.T("stats.nick", playerStats.Nick), app.Theme().Text))
+	app.Draw(gui.NewText(x, y+2, app.T("stats.games", playerStats.Games), app.Theme().Text))
+	app.Draw(gui.NewText(x, y+3, app.T("stats.points", playerStats.Points), app.Theme().Text))
+	app.Draw(gui.NewText(x, y+4, app.T("stats.rank", playerStats.Rank), app.Theme().Text))
+	app.Draw(gui.NewText(x, y+5, app.T("stats.wins", playerStats.Wins), app.Theme().Text))
 }
 
-func drawNameField(ui *gui.GUI, ctx context.Context) string {
+func drawNameField(app *App, ctx context.Context) string {
 	buttonConfig := gui.NewButtonConfig()
 	buttonConfig.Height = 3
 	buttonConfig.Width = 9
-	buttonConfig.BgColor = gui.Green
-	nametext := gui.NewText(2, 2, "Enter your new username here", defaultText)
+	buttonConfig.FgColor = app.Theme().ButtonText
+	buttonConfig.BgColor = app.Theme().Success
+	nametext := gui.NewText(2, 2, app.T("profile.enterName"), app.Theme().Text)
 	usernameField := gui.NewTextInput(2, 4, 20)
-	saveButton := gui.NewButton(2, 5, "Save", buttonConfig)
+	saveButton := gui.NewButton(2, 5, app.T("common.save"), buttonConfig)
 	x, _ := saveButton.Position()
 	w, _ := saveButton.Size()
-	buttonConfig.BgColor = gui.Red
-	cancelButton := gui.NewButton(x+w+2, 5, "Cancel", buttonConfig)
+	buttonConfig.BgColor = app.Theme().Danger
+	cancelButton := gui.NewButton(x+w+2, 5, app.T("common.cancel"), buttonConfig)
 	buttonMapping := map[string]gui.Spatial{
 		"saveButton":   saveButton,
 		"cancelButton": cancelButton,
 	}
-	buttonArea := gui.NewHandleArea(buttonMapping)
+	buttonArea := app.NewHandleArea(buttonMapping)
 
 	// Draw all objects
 	drawables := []gui.Drawable{
@@ -241,37 +299,42 @@ func drawNameField(ui *gui.GUI, ctx context.Context) string {
 		saveButton,
 	}
 	for _, drawable := range drawables {
-		ui.Draw(drawable)
+		app.Draw(drawable)
 	}
 
 	for {
-		clicked := buttonArea.Listen(ctx)
+		clicked := app.ListenArea(ctx, buttonArea)
 		switch clicked {
 		case "saveButton":
-			return usernameField.GetContent()
+			return app.ReadInput(usernameField)
 		case "cancelButton":
 			return ""
 		}
+		// Nothing is clicked once the screen is left
+		if clicked == "" || ctx.Err() != nil {
+			return ""
+		}
 	}
 }
 
-func drawDescField(ui *gui.GUI, ctx context.Context) string {
+func drawDescField(app *App, ctx context.Context) string {
 	buttonConfig := gui.NewButtonConfig()
 	buttonConfig.Height = 3
 	buttonConfig.Width = 9
-	buttonConfig.BgColor = gui.Green
-	desctext := gui.NewText(2, 2, "Enter your new description here", defaultText)
+	buttonConfig.FgColor = app.Theme().ButtonText
+	buttonConfig.BgColor = app.Theme().Success
+	desctext := gui.NewText(2, 2, app.T("profile.enterDesc"), app.Theme().Text)
 	descriptionField := gui.NewTextInput(2, 4, 20)
-	saveButton := gui.NewButton(2, 5, "Save", buttonConfig)
+	saveButton := gui.NewButton(2, 5, app.T("common.save"), buttonConfig)
 	x, _ := saveButton.Position()
 	w, _ := saveButton.Size()
-	buttonConfig.BgColor = gui.Red
-	cancelButton := gui.NewButton(x+w+2, 5, "Cancel", buttonConfig)
+	buttonConfig.BgColor = app.Theme().Danger
+	cancelButton := gui.NewButton(x+w+2, 5, app.T("common.cancel"), buttonConfig)
 	buttonMapping := map[string]gui.Spatial{
 		"saveButton":   saveButton,
 		"cancelButton": cancelButton,
 	}
-	buttonArea := gui.NewHandleArea(buttonMapping)
+	buttonArea := app.NewHandleArea(buttonMapping)
 
 	// Draw all objects
 	drawables := []gui.Drawable{
@@ -282,44 +345,78 @@ func drawDescField(ui *gui.GUI, ctx context.Context) string {
 		saveButton,
 	}
 	for _, drawable := range drawables {
-		ui.Draw(drawable)
+		app.Draw(drawable)
 	}
 
 	for {
-		clicked := buttonArea.Listen(ctx)
+		clicked := app.ListenArea(ctx, buttonArea)
 		switch clicked {
 		case "saveButton":
-			return descriptionField.GetContent()
+			return app.ReadInput(descriptionField)
 		case "cancelButton":
 			return ""
 		}
+		// Nothing is clicked once the screen is left
+		if clicked == "" || ctx.Err() != nil {
+			return ""
+		}
 	}
 }
 
-var remaining int
-var mu sync.Mutex
+// How long the server keeps a player in the lobby without a refresh, until its timer was seen
+const defaultLobbyWait = 15 * time.Second
 
-func lobbyTimer(ctx context.Context, reset chan bool, ui *gui.GUI) {
+// lobbyTimer shows how long until the server drops us from the lobby. It's the timer
+// reported by the server if there is one, which the session learns the lobby wait time
+// from, otherwise it's counted down from the deadline of the session, which is moved
+// after every refresh. It's shown in the last row of the lobby status.
+func lobbyTimer(ctx context.Context, app *App, status layout.Rect, session *lobbySession) {
 	ticker := time.NewTicker(1 * time.Second)
 	defer ticker.Stop()
 
-	remaining = 15
-
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case <-reset:
-			remaining = 15
 		case <-ticker.C:
-			mu.Lock()
-			remaining--
-			mu.Unlock()
-			ui.Draw(gui.NewText(2, 4, fmt.Sprintf("Time remaining: %d seconds", remaining), defaultText))
-
-			if remaining == 0 {
-				return
+			remaining := max(int(time.Until(session.Deadline()).Seconds()), 0)
+			source := app.T("lobby.estimated")
+			if response, err := app.API.GetGameStatus(session.playerToken); err == nil {
+				var gameStatus GameStatusResponse
+				if json.Unmarshal([]byte(response), &gameStatus) == nil && gameStatus.GameStatus == "waiting" && gameStatus.Timer > 0 {
+					remaining, source = gameStatus.Timer, app.T("lobby.server")
+					session.serverTimer(time.Duration(gameStatus.Timer) * time.Second)
+				}
 			}
+			statusMessage(app, status.Rows(1, 1, 1)[2], app.T("lobby.remaining", remaining, source), app.Theme().Text)
+		}
+	}
+}
+
+// lobbyKeepAlive refreshes our place in the lobby a few times per lobby wait until ctx is
+// cancelled, which happens when the game starts, the player leaves the lobby or turns it off.
+// Failed refreshes are retried sooner with a delay that doubles after every failure.
+// Refreshes are reported in the middle row of the lobby status.
+func lobbyKeepAlive(ctx context.Context, app *App, status layout.Rect, session *lobbySession) {
+	status = status.Rows(1, 1, 1)[1]
+	delay := session.keepAliveInterval()
+	backoff := 500 * time.Millisecond
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
 		}
+
+		if err := app.API.RefreshLobby(session.playerToken); err != nil {
+			delay = min(backoff, session.keepAliveInterval())
+			backoff *= 2
+			statusMessage(app, status, app.T("lobby.keepAliveFailed", delay, err), app.Theme().Error)
+			continue
+		}
+
+		delay, backoff = session.keepAliveInterval(), 500*time.Millisecond
+		session.refreshed()
+		statusMessage(app, status, app.T("lobby.refreshed", time.Now().Format("15:04:05")), app.Theme().Text)
 	}
 }
@@ -0,0 +1,180 @@
+package client
+
+import (
+	"BomboweStatki/layout"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	gui "github.com/s25867/warships-gui/v2"
+)
+
+// Rows of the panel statistics at the top of both info columns, nicks and descriptions go below
+const panelStatRows = 4
+
+// gamePanel is the status panel below the boards. The board goroutines report
+// shots and turns to it, and it is drawn again whenever something changes.
+type gamePanel struct {
+	app *App
+
+	mu            sync.Mutex
+	ourTurn       bool
+	ended         bool
+	shotsFired    int
+	hits          int
+	streak        int // hits in a row, reset by a miss
+	bestStreak    int
+	enemySunk     []int // sizes of enemy ships we sank
+	shotsReceived int
+	hitsReceived  int
+	ourSunk       []int // sizes of our ships the opponent sank
+	warning       string
+	drawn         []gui.Drawable
+}
+
+func newGamePanel(app *App) *gamePanel {
+	panel := &gamePanel{app: app}
+	panel.draw()
+	return panel
+}
+
+// turn shows whose turn it is, false when the game ended
+func (p *gamePanel) turn(ourTurn, ended bool) {
+	p.mu.Lock()
+	changed := p.ourTurn != ourTurn || p.ended != ended
+	p.ourTurn, p.ended = ourTurn, ended
+	if changed {
+		p.warning = ""
+	}
+	p.mu.Unlock()
+	if changed {
+		slog.Debug("turn changed", "ours", ourTurn, "ended", ended)
+	}
+	if changed {
+		p.draw()
+	}
+}
+
+// warn shows the warning in the turn banner until the turn changes
+func (p *gamePanel) warn(warning string) {
+	p.mu.Lock()
+	p.warning = warning
+	p.mu.Unlock()
+	p.draw()
+}
+
+// fired records the result of our shot, sunkSize is the size of the ship we sank, if any
+func (p *gamePanel) fired(result string, sunkSize int) {
+	p.mu.Lock()
+	p.shotsFired++
+	switch result {
+	case "hit", "sunk":
+		p.hits++
+		p.streak++
+		p.bestStreak = max(p.bestStreak, p.streak)
+	default:
+		p.streak = 0
+	}
+	if result == "sunk" && sunkSize > 0 {
+		p.enemySunk = append(p.enemySunk, sunkSize)
+	}
+	p.mu.Unlock()
+	p.draw()
+}
+
+// received sets the shots of the opponent so far and sizes of our sunk ships
+func (p *gamePanel) received(shots, hits int, ourSunk []int) {
+	p.mu.Lock()
+	changed := p.shotsReceived != shots || p.hitsReceived != hits
+	p.shotsReceived, p.hitsReceived, p.ourSunk = shots, hits, ourSunk
+	p.mu.Unlock()
+	if changed {
+		p.draw()
+	}
+}
+
+// afloat lists sizes of the fleet that are not sunk, biggest first
+func afloat(sunk []int) string {
+	left := append([]int(nil), fleetSizes...)
+	for _, size := range sunk {
+		for i, s := range left {
+			if s == size {
+				left = append(left[:i], left[i+1:]...)
+				break
+			}
+		}
+	}
+	sizes := make([]string, len(left))
+	for i, size := range left {
+		sizes[i] = fmt.Sprint(size)
+	}
+	return fmt.Sprintf("%s (%d/%d)", strings.Join(sizes, " "), len(left), len(fleetSizes))
+}
+
+func (p *gamePanel) draw() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	app := p.app
+	for _, drawable := range p.drawn {
+		app.Remove(drawable)
+	}
+
+	// The turn banner spans the controls row so it can't be missed, our side
+	// of the statistics goes under our board and the opponent side under theirs
+	g := layout.Game()
+	bannerConfig := gui.NewButtonConfig()
+	bannerConfig.Height = g.Controls.H
+	bannerConfig.Width = g.Controls.W
+	bannerConfig.FgColor = app.Theme().ButtonText
+	var banner string
+	switch {
+	case p.ended:
+		bannerConfig.BgColor = app.Theme().Muted
+		banner = app.T("panel.ended")
+	case p.ourTurn && p.warning != "":
+		bannerConfig.BgColor = app.Theme().Highlight
+		banner = p.warning
+	case p.ourTurn:
+		bannerConfig.BgColor = app.Theme().Success
+		banner = app.T("panel.ourTurn")
+	default:
+		bannerConfig.BgColor = app.Theme().Danger
+		banner = app.T("panel.opponentTurn")
+	}
+
+	accuracy := app.T("game.accuracyUnknown")
+	if p.shotsFired > 0 {
+		accuracy = app.T("game.accuracy", float64(p.hits)/float64(p.shotsFired)*100)
+	}
+	left, right := g.PlayerInfo, g.OpponentInfo
+	p.drawn = []gui.Drawable{
+		gui.NewButton(g.Controls.X, g.Controls.Y, banner, bannerConfig),
+		gui.NewText(left.X, left.Y, fitText(app.T("panel.ourFleet", afloat(p.ourSunk)), left.W-1), app.Theme().Text),
+		gui.NewText(left.X, left.Y+1, fitText(app.T("panel.received", p.shotsReceived, p.hitsReceived), left.W-1), app.Theme().Text),
+		gui.NewText(right.X, right.Y, fitText(app.T("panel.enemyFleet", afloat(p.enemySunk)), right.W-1), app.Theme().Text),
+		gui.NewText(right.X, right.Y+1, fitText(app.T("panel.fired", p.shotsFired, p.hits), right.W-1), app.Theme().Text),
+		gui.NewText(right.X, right.Y+2, fitText(accuracy, right.W-1), app.Theme().Text),
+		gui.NewText(right.X, right.Y+3, fitText(app.T("panel.streak", p.streak, p.bestStreak), right.W-1), app.Theme().HighlightText),
+	}
+	for _, drawable := range p.drawn {
+		app.Draw(drawable)
+	}
+}
+
+// fitText pads or cuts the text to the width, so it covers what was drawn there before
+func fitText(text string, width int) string {
+	width = max(width, 0)
+	return fmt.Sprintf("%-*.*s", width, width, text)
+}
+
+// statusMessage shows a message in the first row of the area, over the previous one
+func statusMessage(app *App, area layout.Rect, message string, config *gui.TextConfig) {
+	app.Draw(gui.NewText(area.X, area.Y, fitText(message, area.W), config))
+}
+
+// gameMessage shows a message in the second status row above the boards, over the previous one
+func gameMessage(app *App, message string, config *gui.TextConfig) {
+	status := layout.Game().Status
+	app.Draw(gui.NewText(status.X, status.Y+1, fitText(message, status.W), config))
+}
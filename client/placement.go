@@ -0,0 +1,370 @@
+package client
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Sizes of all ships that make up a complete fleet
+var fleetSizes = []int{4, 3, 3, 2, 2, 2, 1, 1, 1, 1}
+
+// placementError is why a ship can't be placed, key is its message in the catalogs so the
+// editor can show it in the language of the app. Error returns the English message.
+type placementError struct {
+	key  string
+	args []any
+}
+
+func (e placementError) Error() string {
+	message := fmt.Sprintf(messagesEN[e.key], e.args...)
+	return strings.ToLower(message[:1]) + message[1:]
+}
+
+// placementMessage returns err in the language of the app if it's a placementError
+func placementMessage(app *App, err error) string {
+	var placement placementError
+	if errors.As(err, &placement) {
+		return app.T(placement.key, placement.args...)
+	}
+	return err.Error()
+}
+
+// placedShip is a ship on the board, its shape is stored relative to the anchor cell.
+// Ships don't have to be straight, any shape of connected cells is allowed.
+type placedShip struct {
+	Col   int // 0 = A
+	Row   int // 0 = 1
+	Shape [][2]int
+}
+
+func newStraightShip(col, row, size int) placedShip {
+	shape := make([][2]int, 0, size)
+	for i := 0; i < size; i++ {
+		shape = append(shape, [2]int{i, 0})
+	}
+	return placedShip{Col: col, Row: row, Shape: shape}
+}
+
+func (s placedShip) size() int {
+	return len(s.Shape)
+}
+
+func (s placedShip) cells() [][2]int {
+	cells := make([][2]int, 0, len(s.Shape))
+	for _, offset := range s.Shape {
+		cells = append(cells, [2]int{s.Col + offset[0], s.Row + offset[1]})
+	}
+	return cells
+}
+
+func (s placedShip) coords() []string {
+	coords := make([]string, 0, len(s.Shape))
+	for _, cell := range s.cells() {
+		coords = append(coords, indexToCoord(cell[0], cell[1]))
+	}
+	return coords
+}
+
+func (s placedShip) contains(col, row int) bool {
+	for _, cell := range s.cells() {
+		if cell[0] == col && cell[1] == row {
+			return true
+		}
+	}
+	return false
+}
+
+// rotated returns the ship turned by 90 degrees clockwise around its anchor
+func (s placedShip) rotated() placedShip {
+	shape := make([][2]int, 0, len(s.Shape))
+	for _, offset := range s.Shape {
+		shape = append(shape, [2]int{-offset[1], offset[0]})
+	}
+	return placedShip{Col: s.Col, Row: s.Row, Shape: normalizeShape(shape)}
+}
+
+// touches reports whether any cell of s is on or next to (also diagonally) a cell of other
+func (s placedShip) touches(other placedShip) bool {
+	for _, a := range s.cells() {
+		for _, b := range other.cells() {
+			if abs(a[0]-b[0]) <= 1 && abs(a[1]-b[1]) <= 1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalizeShape moves the shape so its top left corner is at 0,0
+func normalizeShape(shape [][2]int) [][2]int {
+	minCol, minRow := shape[0][0], shape[0][1]
+	for _, offset := range shape {
+		minCol, minRow = min(minCol, offset[0]), min(minRow, offset[1])
+	}
+	normalized := make([][2]int, 0, len(shape))
+	for _, offset := range shape {
+		normalized = append(normalized, [2]int{offset[0] - minCol, offset[1] - minRow})
+	}
+	return normalized
+}
+
+// shipShapes returns every shape a ship of the size can have, cells connected side by side
+// in any way, normalized so each shape is listed once
+func shipShapes(size int) [][][2]int {
+	shapes := [][][2]int{{{0, 0}}}
+	for n := 1; n < size; n++ {
+		seen := make(map[string]bool)
+		var grown [][][2]int
+		for _, shape := range shapes {
+			for _, cell := range shape {
+				for _, d := range [][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+					next := [2]int{cell[0] + d[0], cell[1] + d[1]}
+					if slices.Contains(shape, next) {
+						continue
+					}
+					bigger := normalizeShape(append(slices.Clone(shape), next))
+					slices.SortFunc(bigger, func(a, b [2]int) int {
+						return cmp.Or(cmp.Compare(a[0], b[0]), cmp.Compare(a[1], b[1]))
+					})
+					if key := fmt.Sprint(bigger); !seen[key] {
+						seen[key] = true
+						grown = append(grown, bigger)
+					}
+				}
+			}
+		}
+		shapes = grown
+	}
+	return shapes
+}
+
+// placementEditor keeps the state of the ship layout editor with undo and redo history
+type placementEditor struct {
+	ships []placedShip
+	undo  [][]placedShip
+	redo  [][]placedShip
+}
+
+func newPlacementEditor(coords []string) *placementEditor {
+	editor := &placementEditor{}
+	ships, err := layoutFromCoords(coords)
+	if err == nil {
+		editor.ships = ships
+	}
+	return editor
+}
+
+func (e *placementEditor) snapshot() []placedShip {
+	ships := make([]placedShip, len(e.ships))
+	copy(ships, e.ships)
+	return ships
+}
+
+// saveHistory stores the current state before a change, any redo history is dropped
+func (e *placementEditor) saveHistory() {
+	e.undo = append(e.undo, e.snapshot())
+	e.redo = nil
+}
+
+// remaining returns sizes of ships that still have to be placed, largest first
+func (e *placementEditor) remaining() []int {
+	left := make(map[int]int)
+	for _, size := range fleetSizes {
+		left[size]++
+	}
+	for _, ship := range e.ships {
+		left[ship.size()]--
+	}
+
+	sizes := make([]int, 0)
+	for size, count := range left {
+		for i := 0; i < count; i++ {
+			sizes = append(sizes, size)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(sizes)))
+	return sizes
+}
+
+func (e *placementEditor) remainingOfSize(size int) int {
+	count := 0
+	for _, s := range e.remaining() {
+		if s == size {
+			count++
+		}
+	}
+	return count
+}
+
+func (e *placementEditor) complete() bool {
+	return len(e.remaining()) == 0
+}
+
+// canPlace checks if the ship fits on the board without touching already placed ships
+func (e *placementEditor) canPlace(ship placedShip) error {
+	if e.remainingOfSize(ship.size()) == 0 {
+		return placementError{"editor.noShipsLeft", []any{ship.size()}}
+	}
+	for _, cell := range ship.cells() {
+		if cell[0] < 0 || cell[0] > 9 || cell[1] < 0 || cell[1] > 9 {
+			return placementError{key: "editor.offBoard"}
+		}
+	}
+	for _, other := range e.ships {
+		if ship.touches(other) {
+			return placementError{key: "editor.touching"}
+		}
+	}
+	return nil
+}
+
+func (e *placementEditor) place(ship placedShip) error {
+	if err := e.canPlace(ship); err != nil {
+		return err
+	}
+	e.saveHistory()
+	e.ships = append(e.ships, ship)
+	return nil
+}
+
+// pickUp removes the ship occupying the given cell so it can be moved
+func (e *placementEditor) pickUp(col, row int) (placedShip, bool) {
+	for i, ship := range e.ships {
+		if ship.contains(col, row) {
+			e.saveHistory()
+			e.ships = append(e.ships[:i:i], e.ships[i+1:]...)
+			return ship, true
+		}
+	}
+	return placedShip{}, false
+}
+
+func (e *placementEditor) undoLast() bool {
+	if len(e.undo) == 0 {
+		return false
+	}
+	e.redo = append(e.redo, e.snapshot())
+	e.ships = e.undo[len(e.undo)-1]
+	e.undo = e.undo[:len(e.undo)-1]
+	return true
+}
+
+func (e *placementEditor) redoLast() bool {
+	if len(e.redo) == 0 {
+		return false
+	}
+	e.undo = append(e.undo, e.snapshot())
+	e.ships = e.redo[len(e.redo)-1]
+	e.redo = e.redo[:len(e.redo)-1]
+	return true
+}
+
+func (e *placementEditor) clear() {
+	if len(e.ships) == 0 {
+		return
+	}
+	e.saveHistory()
+	e.ships = nil
+}
+
+// autoComplete places all remaining ships at random positions
+func (e *placementEditor) autoComplete() error {
+	if e.complete() {
+		return nil
+	}
+	for attempt := 0; attempt < 50; attempt++ {
+		if placed, ok := randomPlacement(e.snapshot(), e.remaining()); ok {
+			e.saveHistory()
+			e.ships = placed
+			return nil
+		}
+	}
+	return placementError{key: "editor.noRoom"}
+}
+
+func randomPlacement(ships []placedShip, sizes []int) ([]placedShip, bool) {
+	tmp := &placementEditor{ships: ships}
+	for _, size := range sizes {
+		placed := false
+		for try := 0; try < 200 && !placed; try++ {
+			ship := newStraightShip(rand.Intn(10), rand.Intn(10), size)
+			if rand.Intn(2) == 0 {
+				ship = ship.rotated()
+			}
+			if tmp.canPlace(ship) == nil {
+				tmp.ships = append(tmp.ships, ship)
+				placed = true
+			}
+		}
+		if !placed {
+			return nil, false
+		}
+	}
+	return tmp.ships, true
+}
+
+func (e *placementEditor) coords() []string {
+	coords := make([]string, 0, 20)
+	for _, ship := range e.ships {
+		coords = append(coords, ship.coords()...)
+	}
+	return coords
+}
+
+// layoutFromCoords turns a list of coordinates into ships and checks that they form a valid fleet
+func layoutFromCoords(coords []string) ([]placedShip, error) {
+	for _, coord := range coords {
+		if _, _, err := coordToIndex(coord); err != nil {
+			return nil, err
+		}
+	}
+	if len(removeDuplicates(coords)) != len(coords) {
+		return nil, errors.New("layout contains duplicate coordinates")
+	}
+
+	editor := &placementEditor{}
+	for _, group := range mapShips(coords) {
+		ship := shipFromCoords(group.Coords)
+		if err := editor.canPlace(ship); err != nil {
+			return nil, fmt.Errorf("ship at %s: %v", group.Coords[0], err)
+		}
+		editor.ships = append(editor.ships, ship)
+	}
+	if !editor.complete() {
+		return nil, fmt.Errorf("layout is missing ships of sizes %v", editor.remaining())
+	}
+	return editor.ships, nil
+}
+
+// ValidateLayout checks if coordinates describe a complete fleet with no touching ships
+func ValidateLayout(coords []string) error {
+	_, err := layoutFromCoords(coords)
+	return err
+}
+
+// RandomLayout returns coordinates of a complete fleet placed at random
+func RandomLayout() []string {
+	for {
+		editor := &placementEditor{}
+		if editor.autoComplete() == nil {
+			return editor.coords()
+		}
+	}
+}
+
+// shipFromCoords builds a ship from connected coordinates, anchored at its top left corner
+func shipFromCoords(coords []string) placedShip {
+	cells := make([][2]int, 0, len(coords))
+	for _, coord := range coords {
+		col, row, _ := coordToIndex(coord)
+		cells = append(cells, [2]int{col, row})
+	}
+	shape := normalizeShape(cells)
+	minCol, minRow := cells[0][0]-shape[0][0], cells[0][1]-shape[0][1]
+	return placedShip{Col: minCol, Row: minRow, Shape: shape}
+}
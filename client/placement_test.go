@@ -0,0 +1,151 @@
+package client
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestPlacementEditorPlace(t *testing.T) {
+	tests := []struct {
+		name    string
+		placed  []placedShip
+		ship    placedShip
+		wantErr bool
+	}{
+		{"empty board", nil, newStraightShip(0, 0, 4), false},
+		{"off the board", nil, newStraightShip(8, 0, 4), true},
+		{"overlapping", []placedShip{newStraightShip(0, 0, 4)}, newStraightShip(2, 0, 2), true},
+		{"touching diagonally", []placedShip{newStraightShip(0, 0, 1)}, newStraightShip(1, 1, 1), true},
+		{"one cell apart", []placedShip{newStraightShip(0, 0, 1)}, newStraightShip(2, 0, 1), false},
+		{"no ships of the size left", []placedShip{newStraightShip(0, 0, 4)}, newStraightShip(0, 5, 4), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			editor := &placementEditor{ships: slices.Clone(tt.placed)}
+			err := editor.place(tt.ship)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("place() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			want := len(tt.placed)
+			if !tt.wantErr {
+				want++
+			}
+			if len(editor.ships) != want {
+				t.Errorf("editor has %d ships, want %d", len(editor.ships), want)
+			}
+		})
+	}
+}
+
+func TestPlacementErrorsInBothCatalogs(t *testing.T) {
+	editor := &placementEditor{ships: []placedShip{newStraightShip(0, 0, 4)}}
+	for _, ship := range []placedShip{newStraightShip(8, 5, 3), newStraightShip(0, 1, 3), newStraightShip(0, 5, 4)} {
+		var placement placementError
+		if err := editor.canPlace(ship); !errors.As(err, &placement) {
+			t.Fatalf("canPlace(%v) = %v, want a placementError", ship, err)
+		}
+		for language, catalog := range catalogs {
+			if _, ok := catalog[placement.key]; !ok {
+				t.Errorf("%s catalog has no message for %q", language, placement.key)
+			}
+		}
+	}
+}
+
+func TestShipShapes(t *testing.T) {
+	for size, want := range map[int]int{1: 1, 2: 2, 3: 6, 4: 19} {
+		shapes := shipShapes(size)
+		if len(shapes) != want {
+			t.Errorf("shipShapes(%d) has %d shapes, want %d", size, len(shapes), want)
+		}
+		for _, shape := range shapes {
+			if ships := mapShips(placedShip{Shape: shape}.coords()); len(ships) != 1 {
+				t.Errorf("shape %v of size %d makes %d ships, want 1", shape, size, len(ships))
+			}
+		}
+	}
+}
+
+func TestPlacementEditorUndoRedo(t *testing.T) {
+	editor := newPlacementEditor(nil)
+	if err := editor.place(newStraightShip(0, 0, 4)); err != nil {
+		t.Fatal(err)
+	}
+	if err := editor.place(newStraightShip(0, 2, 3)); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := editor.pickUp(1, 2); !ok {
+		t.Fatal("pickUp() found no ship at B3")
+	}
+	if got := editor.remainingOfSize(3); got != 2 {
+		t.Errorf("remainingOfSize(3) after pickUp = %d, want 2", got)
+	}
+
+	// Undo goes back through the pick up and both drops
+	for i, want := range []int{2, 1, 0} {
+		if !editor.undoLast() {
+			t.Fatalf("undo %d failed", i+1)
+		}
+		if len(editor.ships) != want {
+			t.Errorf("after %d undos editor has %d ships, want %d", i+1, len(editor.ships), want)
+		}
+	}
+	if editor.undoLast() {
+		t.Error("undoLast() with no history succeeded")
+	}
+	if !editor.redoLast() || len(editor.ships) != 1 {
+		t.Errorf("after redo editor has %d ships, want 1", len(editor.ships))
+	}
+	// A new change drops what could be redone
+	editor.clear()
+	if editor.redoLast() {
+		t.Error("redoLast() after a change succeeded")
+	}
+}
+
+func TestPlacementEditorAutoComplete(t *testing.T) {
+	editor := newPlacementEditor(nil)
+	if err := editor.place(newStraightShip(0, 0, 4)); err != nil {
+		t.Fatal(err)
+	}
+	if err := editor.autoComplete(); err != nil {
+		t.Fatalf("autoComplete() error = %v", err)
+	}
+	if !editor.complete() {
+		t.Errorf("fleet is not complete, remaining %v", editor.remaining())
+	}
+	if err := ValidateLayout(editor.coords()); err != nil {
+		t.Errorf("auto-completed layout is invalid: %v", err)
+	}
+}
+
+func TestValidateLayout(t *testing.T) {
+	valid := []string{
+		"A1", "A2", "A3", "A4",
+		"C1", "C2", "C3",
+		"E1", "E2", "E3",
+		"G1", "G2",
+		"I1", "I2",
+		"A6", "A7",
+		"C5", "E5", "G5", "I5",
+	}
+	tests := []struct {
+		name    string
+		coords  []string
+		wantErr bool
+	}{
+		{"complete fleet", valid, false},
+		{"missing a ship", valid[:len(valid)-1], true},
+		{"duplicate coordinate", append(slices.Clone(valid[:len(valid)-1]), "A1"), true},
+		{"off the board", append(slices.Clone(valid[:len(valid)-1]), "K5"), true},
+		{"touching ships", append(slices.Clone(valid[:len(valid)-1]), "B5"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateLayout(tt.coords); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateLayout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
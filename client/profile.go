@@ -0,0 +1,99 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Directory where player profiles are stored
+var profilesDir = "profiles"
+
+// Profile is the part of game data that is kept between runs
+type Profile struct {
+	Nick   string   `json:"nick"`
+	Desc   string   `json:"desc"`
+	Coords []string `json:"coords"`
+	// Refresh our place in the lobby automatically while waiting for a challenger
+	KeepAlive  bool               `json:"keep_alive,omitempty"`
+	QuickMatch QuickMatchSettings `json:"quick_match"`
+	// Name of the colour theme, see themeNames
+	Theme string `json:"theme,omitempty"`
+	// Language of the UI, see languages
+	Language string       `json:"language,omitempty"`
+	Game     GameSettings `json:"game"`
+}
+
+// GameData returns game data with the nick, description and layout of the profile
+func (p Profile) GameData() GameInitData {
+	return GameInitData{Nick: p.Nick, Desc: p.Desc, Coords: p.Coords}
+}
+
+// ErrInvalidLayout is returned with a usable profile whose saved layout was replaced by the default one
+var ErrInvalidLayout = errors.New("invalid layout")
+
+func profilePath(name string) string {
+	return filepath.Join(profilesDir, safeFileName(name)+".json")
+}
+
+// LoadProfile reads the named profile, fields missing in it are filled with defaults.
+// A profile that doesn't exist yet is created from the defaults on first save.
+// An invalid saved layout is replaced by the default one and ErrInvalidLayout is returned.
+func LoadProfile(name string) (Profile, error) {
+	defaults := defaultGameInitData()
+	profile := Profile{Nick: defaults.Nick, Desc: defaults.Desc, Coords: defaults.Coords, QuickMatch: defaultQuickMatch, Game: defaultGameSettings}
+
+	data, err := os.ReadFile(profilePath(name))
+	if os.IsNotExist(err) {
+		return profile, nil
+	}
+	if err != nil {
+		return profile, fmt.Errorf("error reading profile: %w", err)
+	}
+
+	var saved Profile
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return profile, fmt.Errorf("error parsing profile %s: %w", name, err)
+	}
+	if saved.Nick != "" {
+		profile.Nick = saved.Nick
+	}
+	if saved.Desc != "" {
+		profile.Desc = saved.Desc
+	}
+	var layoutErr error
+	if len(saved.Coords) > 0 {
+		if err := ValidateLayout(saved.Coords); err != nil {
+			layoutErr = fmt.Errorf("%w in profile %s, using the default one: %v", ErrInvalidLayout, name, err)
+		} else {
+			profile.Coords = saved.Coords
+		}
+	}
+	profile.KeepAlive = saved.KeepAlive
+	profile.Theme = saved.Theme
+	profile.Language = saved.Language
+	if saved.Game.Strategy != "" {
+		profile.Game = saved.Game
+	}
+	if saved.QuickMatch.Wait > 0 {
+		profile.QuickMatch = saved.QuickMatch
+	}
+	return profile, layoutErr
+}
+
+// SaveProfile writes the profile under the given name
+func SaveProfile(name string, profile Profile) error {
+	data, err := json.MarshalIndent(profile, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(profilesDir, 0755); err != nil {
+		return fmt.Errorf("error creating profiles directory: %w", err)
+	}
+	if err := os.WriteFile(profilePath(name), data, 0644); err != nil {
+		return fmt.Errorf("error saving profile: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"slices"
+	"sync"
+
+	gui "github.com/s25867/warships-gui/v2"
+)
+
+// boardSync is our layout as the server has it, our board as it was last drawn and what we
+// know about the opponent board. Our board is rebuilt from the server data with every poll
+// of opp_shots. The server doesn't send our own shots, so the opponent board can't be
+// checked against it: it comes from the FireAtEnemy responses only, and a shot whose
+// response was lost stays unknown and can be fired again.
+type boardSync struct {
+	mu         sync.Mutex
+	layout     []string
+	player     [10][10]gui.State
+	drawnShots int // opponent shots drawn on our board
+	opponent   targetGrid
+}
+
+func newBoardSync(layout []string, player [10][10]gui.State) *boardSync {
+	return &boardSync{layout: layout, player: player}
+}
+
+// target returns what we know about the opponent board
+func (s *boardSync) target() targetGrid {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.opponent
+}
+
+// fired adds the result of our shot, it returns the cells of the ship it sank and what we know now
+func (s *boardSync) fired(coord, result string) (sunkShip [][2]int, known targetGrid) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sunkShip = s.opponent.record(coord, result)
+	return sunkShip, s.opponent
+}
+
+// rebuild draws our board from the layout on the server and all shots of the opponent. Results
+// and sunk are as in playerBoardFromServer, diverged are the cells where what we drew before
+// differs from the server data, because our layout or the shot history there is different.
+func (s *boardSync) rebuild(oppShots []string) (states [10][10]gui.State, results []string, sunk []int, diverged []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	states, results, sunk = playerBoardFromServer(s.layout, oppShots)
+	before := states
+	if s.drawnShots <= len(oppShots) {
+		before, _, _ = playerBoardFromServer(s.layout, oppShots[:s.drawnShots])
+	}
+	diverged = divergentCells(s.player, before)
+	s.player, s.drawnShots = states, len(oppShots)
+	return states, results, sunk, diverged
+}
+
+// playerBoardFromServer rebuilds our board from our layout and the shots of the opponent.
+// Results are what each shot did when it was fired, sunk are sizes of our sunk ships.
+func playerBoardFromServer(layout, oppShots []string) (states [10][10]gui.State, results []string, sunk []int) {
+	for col := range states {
+		for row := range states[col] {
+			states[col][row] = gui.Empty
+		}
+	}
+	markStates(&states, layout, gui.Ship)
+
+	ships := mapShips(layout)
+	hit := map[string]bool{}
+	for _, coord := range oppShots {
+		if !slices.Contains(layout, coord) {
+			markStates(&states, []string{coord}, gui.Miss)
+			results = append(results, "miss")
+			continue
+		}
+		hit[coord] = true
+		ship := shipContaining(ships, coord)
+		sunkNow := true
+		for _, shipCoord := range ship {
+			sunkNow = sunkNow && hit[shipCoord]
+		}
+		if sunkNow {
+			markStates(&states, ship, gui.Sunk)
+			results = append(results, "sunk")
+			sunk = append(sunk, len(ship))
+		} else {
+			markStates(&states, []string{coord}, gui.Hit)
+			results = append(results, "hit")
+		}
+	}
+	return states, results, sunk
+}
+
+// divergentCells returns coordinates of cells that differ between the two boards
+func divergentCells(local, server [10][10]gui.State) []string {
+	var cells []string
+	for col := 0; col < 10; col++ {
+		for row := 0; row < 10; row++ {
+			if local[col][row] != server[col][row] {
+				cells = append(cells, indexToCoord(col, row))
+			}
+		}
+	}
+	return cells
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"slices"
+	"testing"
+
+	gui "github.com/s25867/warships-gui/v2"
+)
+
+func TestPlayerBoardFromServer(t *testing.T) {
+	layout := []string{"A1", "A2", "C1", "E5", "E6", "E7"}
+	tests := []struct {
+		name        string
+		oppShots    []string
+		wantResults []string
+		wantSunk    []int
+		wantStates  map[string]gui.State
+	}{
+		{
+			name:       "no shots",
+			wantStates: map[string]gui.State{"A1": gui.Ship, "B1": gui.Empty},
+		},
+		{
+			name:        "hit and miss",
+			oppShots:    []string{"A1", "B1"},
+			wantResults: []string{"hit", "miss"},
+			wantStates:  map[string]gui.State{"A1": gui.Hit, "A2": gui.Ship, "B1": gui.Miss},
+		},
+		{
+			name:        "sunk ships",
+			oppShots:    []string{"C1", "A2", "A1"},
+			wantResults: []string{"sunk", "hit", "sunk"},
+			wantSunk:    []int{1, 2},
+			wantStates:  map[string]gui.State{"C1": gui.Sunk, "A1": gui.Sunk, "A2": gui.Sunk, "E5": gui.Ship},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			states, results, sunk := playerBoardFromServer(layout, tt.oppShots)
+			if !slices.Equal(results, tt.wantResults) {
+				t.Errorf("results = %v, want %v", results, tt.wantResults)
+			}
+			if !slices.Equal(sunk, tt.wantSunk) {
+				t.Errorf("sunk = %v, want %v", sunk, tt.wantSunk)
+			}
+			for coord, want := range tt.wantStates {
+				col, row, _ := coordToIndex(coord)
+				if got := states[col][row]; got != want {
+					t.Errorf("cell %s = %v, want %v", coord, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBoardSyncRebuild(t *testing.T) {
+	layout := []string{"A1", "A2", "C1"}
+	initial, _, _ := playerBoardFromServer(layout, nil)
+	boards := newBoardSync(layout, initial)
+
+	if _, _, _, diverged := boards.rebuild([]string{"A1"}); len(diverged) != 0 {
+		t.Errorf("rebuild() with a new shot reported divergent cells %v", diverged)
+	}
+	if _, _, _, diverged := boards.rebuild([]string{"A1", "C1"}); len(diverged) != 0 {
+		t.Errorf("rebuild() with a sunk ship reported divergent cells %v", diverged)
+	}
+
+	// The server history no longer has the shot at A1 we drew
+	states, _, _, diverged := boards.rebuild([]string{"B1", "C1"})
+	if !slices.Equal(diverged, []string{"A1", "B1"}) {
+		t.Errorf("rebuild() diverged = %v, want [A1 B1]", diverged)
+	}
+	if col, row, _ := coordToIndex("A1"); states[col][row] != gui.Ship {
+		t.Errorf("cell A1 = %v after the rebuild, want the ship back", states[col][row])
+	}
+
+	// A board drawn from our layout while the server has another one
+	boards = newBoardSync([]string{"A1", "A2", "D1"}, initial)
+	if _, _, _, diverged := boards.rebuild(nil); !slices.Equal(diverged, []string{"C1", "D1"}) {
+		t.Errorf("rebuild() with another layout diverged = %v, want [C1 D1]", diverged)
+	}
+}
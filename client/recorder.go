@@ -0,0 +1,271 @@
+package client
+
+import (
+	board "BomboweStatki/board"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Directory where recorded games are stored
+var gamesDir = "games"
+
+// GameEvent is a single line of a recorded game file
+type GameEvent struct {
+	Type     string    `json:"type"` // start, shot, timer or end
+	Time     time.Time `json:"time"`
+	Nick     string    `json:"nick,omitempty"`
+	Desc     string    `json:"desc,omitempty"`
+	Coords   []string  `json:"coords,omitempty"`
+	Opponent string    `json:"opponent,omitempty"`
+	OppDesc  string    `json:"opp_desc,omitempty"`
+	Mode     string    `json:"mode,omitempty"`    // pvp, wpbot or bombot
+	Shooter  string    `json:"shooter,omitempty"` // player or opponent
+	Coord    string    `json:"coord,omitempty"`
+	Result   string    `json:"result,omitempty"` // hit, miss or sunk
+	Timer    int       `json:"timer,omitempty"`
+	Outcome  string    `json:"outcome,omitempty"` // win or lose
+	Reason   string    `json:"reason,omitempty"`  // finished, timeout, abandoned, exit or error
+}
+
+// gameRecorder writes events of a single game as JSON Lines.
+// All methods can be called on a nil recorder, nothing is recorded then.
+type gameRecorder struct {
+	mu        sync.Mutex
+	file      *os.File
+	encoder   *json.Encoder
+	oppShots  int
+	lastTimer int
+	ended     bool // the outcome is known, nothing more is recorded
+	closed    bool
+	outcome   string
+	reason    string
+}
+
+func newGameRecorder(start GameEvent) (*gameRecorder, error) {
+	if err := os.MkdirAll(gamesDir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating games directory: %w", err)
+	}
+
+	start.Type = "start"
+	start.Time = time.Now()
+	name := fmt.Sprintf("%s_%s.jsonl", start.Time.Format("20060102-150405"), safeFileName(start.Opponent))
+	file, err := os.Create(filepath.Join(gamesDir, name))
+	if err != nil {
+		return nil, fmt.Errorf("error creating game record: %w", err)
+	}
+
+	r := &gameRecorder{file: file, encoder: json.NewEncoder(file), lastTimer: -1}
+	r.write(start)
+	return r, nil
+}
+
+// resumeGameRecorder continues the record of a game that was left when the client exited
+func resumeGameRecorder(path string) (*gameRecorder, error) {
+	record, err := loadGameRecord(path)
+	if err != nil {
+		return nil, err
+	}
+	if record.End.Type != "" {
+		return nil, fmt.Errorf("game record %s has already ended", path)
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("error opening game record: %w", err)
+	}
+
+	r := &gameRecorder{file: file, encoder: json.NewEncoder(file), lastTimer: -1}
+	// opp_shots starts from the first shot again, skip shots that are recorded already
+	for _, shot := range record.Shots {
+		if shot.Shooter == "opponent" {
+			r.oppShots++
+		}
+	}
+	return r, nil
+}
+
+// path returns the file the game is recorded to, empty for a nil recorder
+func (r *gameRecorder) path() string {
+	if r == nil {
+		return ""
+	}
+	return r.file.Name()
+}
+
+func (r *gameRecorder) write(event GameEvent) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	// A failed write shouldn't break the game, the record is just incomplete
+	_ = r.encoder.Encode(event)
+}
+
+func (r *gameRecorder) shot(shooter, coord, result string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.ended {
+		return
+	}
+	r.write(GameEvent{Type: "shot", Shooter: shooter, Coord: coord, Result: result})
+}
+
+// opponentShot records the shot at the given index of opp_shots, the whole list
+// is sent by the server every time so only shots that weren't seen yet are written
+func (r *gameRecorder) opponentShot(index int, coord, result string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	if index != r.oppShots {
+		r.mu.Unlock()
+		return
+	}
+	r.oppShots++
+	r.mu.Unlock()
+	r.shot("opponent", coord, result)
+}
+
+func (r *gameRecorder) timer(value int) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.ended || value == r.lastTimer {
+		return
+	}
+	r.lastTimer = value
+	r.write(GameEvent{Type: "timer", Timer: value})
+}
+
+// end sets how the game ended, the end event is written when the recorder is closed
+func (r *gameRecorder) end(outcome, reason string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.ended {
+		return
+	}
+	r.ended = true
+	r.outcome, r.reason = outcome, reason
+}
+
+// close writes the end event and closes the file. The outcome set by end is used if
+// there is one, otherwise the given reason. A game left with no reason can still be
+// resumed, its record is closed without an end event.
+func (r *gameRecorder) close(reason string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
+	r.closed = true
+	if r.ended {
+		r.write(GameEvent{Type: "end", Outcome: r.outcome, Reason: r.reason})
+	} else if reason != "" {
+		r.write(GameEvent{Type: "end", Reason: reason})
+	}
+	r.ended = true
+	r.file.Close()
+}
+
+// gameRecord is a recorded game loaded back from its file
+type gameRecord struct {
+	Path  string
+	Start GameEvent
+	Shots []GameEvent
+	End   GameEvent // Type is empty if the game wasn't finished
+}
+
+// result is the outcome of the game, or why it ended without one, empty if it wasn't finished
+func (r *gameRecord) result() string {
+	if r.End.Outcome != "" {
+		return r.End.Outcome
+	}
+	return r.End.Reason
+}
+
+func loadGameRecord(path string) (*gameRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening game record: %w", err)
+	}
+	defer file.Close()
+
+	record := &gameRecord{Path: path}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event GameEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("error parsing game record %s: %w", path, err)
+		}
+		switch event.Type {
+		case "start":
+			record.Start = event
+		case "shot":
+			record.Shots = append(record.Shots, event)
+		case "end":
+			record.End = event
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading game record: %w", err)
+	}
+	if record.Start.Type == "" {
+		return nil, fmt.Errorf("game record %s has no start event", path)
+	}
+	// The replay draws the layout, a record edited by hand could have any coordinates
+	if _, _, _, err := board.Config(record.Start.Coords); err != nil {
+		return nil, fmt.Errorf("game record %s has an invalid layout: %w", path, err)
+	}
+	return record, nil
+}
+
+// listGameRecords returns paths of all recorded games, newest first
+func listGameRecords() ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(gamesDir, "*.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+	// File names start with the date so sorting by name sorts by date
+	sort.Sort(sort.Reverse(sort.StringSlice(paths)))
+	return paths, nil
+}
+
+// gameMode describes who we play against, BomBot joins as a normal player so it's recognised by nick
+func gameMode(gameData GameInitData, opponent string) string {
+	switch {
+	case gameData.Wpbot:
+		return "wpbot"
+	case opponent == "BomBot":
+		return "bombot"
+	default:
+		return "pvp"
+	}
+}
+
+func safeFileName(name string) string {
+	if name == "" {
+		return "unknown"
+	}
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' {
+			return r
+		}
+		return '_'
+	}, name)
+}
@@ -0,0 +1,248 @@
+package client
+
+import (
+	board "BomboweStatki/board"
+	"BomboweStatki/layout"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	gui "github.com/s25867/warships-gui/v2"
+)
+
+// replayStep is the state of both boards after a number of moves of a recorded game
+type replayStep struct {
+	PlayerStates   [10][10]gui.State
+	OpponentStates [10][10]gui.State
+	PlayerShots    int
+	PlayerHits     int
+	OpponentShots  int
+	OpponentHits   int
+	SunkShip       []string // ship sunk by the last move, if any
+}
+
+// replayState rebuilds the boards from the start of the game up to the given move
+func replayState(record *gameRecord, moves int) replayStep {
+	var step replayStep
+	step.PlayerStates, step.OpponentStates, _, _ = board.Config(record.Start.Coords)
+	playerShips := mapShips(record.Start.Coords)
+	var opponentHits []string
+
+	for i, shot := range record.Shots[:moves] {
+		col, row, err := coordToIndex(shot.Coord)
+		if err != nil {
+			continue
+		}
+		var sunkShip []string
+
+		if shot.Shooter == "player" {
+			step.PlayerShots++
+			switch shot.Result {
+			case "hit":
+				step.PlayerHits++
+				opponentHits = append(opponentHits, shot.Coord)
+				step.OpponentStates[col][row] = gui.Hit
+			case "sunk":
+				step.PlayerHits++
+				opponentHits = append(opponentHits, shot.Coord)
+				sunkShip = shipContaining(mapShips(opponentHits), shot.Coord)
+				markStates(&step.OpponentStates, sunkShip, gui.Sunk)
+			default:
+				step.OpponentStates[col][row] = gui.Miss
+			}
+		} else {
+			step.OpponentShots++
+			switch shot.Result {
+			case "hit":
+				step.OpponentHits++
+				step.PlayerStates[col][row] = gui.Hit
+			case "sunk":
+				step.OpponentHits++
+				sunkShip = shipContaining(playerShips, shot.Coord)
+				markStates(&step.PlayerStates, sunkShip, gui.Sunk)
+			default:
+				step.PlayerStates[col][row] = gui.Miss
+			}
+		}
+
+		if i == moves-1 {
+			step.SunkShip = sunkShip
+		}
+	}
+	return step
+}
+
+func shipContaining(ships map[int]Ship, coord string) []string {
+	for _, ship := range ships {
+		if findIndex(ship.Coords, coord) != -1 {
+			return ship.Coords
+		}
+	}
+	return []string{coord}
+}
+
+func markStates(states *[10][10]gui.State, coords []string, state gui.State) {
+	for _, coord := range coords {
+		col, row, err := coordToIndex(coord)
+		if err == nil {
+			states[col][row] = state
+		}
+	}
+}
+
+func accuracyText(hits, shots int) string {
+	if shots == 0 {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.2f%%", float64(hits)/float64(shots)*100)
+}
+
+// replaysMenu lists recorded games, clicking one opens the replay viewer
+func replaysScreen() Screen {
+	return Screen{Name: "replays", Enter: replaysMenu}
+}
+
+func replaysMenu(ctx context.Context, app *App) {
+	paths, err := listGameRecords()
+	if err != nil {
+		statusMessage(app, layout.Message(), app.T("error.replays", err), app.Theme().Error)
+	}
+
+	records := make([]*gameRecord, 0, len(paths))
+	for _, path := range paths {
+		record, err := loadGameRecord(path)
+		if err != nil {
+			continue
+		}
+		records = append(records, record)
+	}
+
+	replaysUi := ReplayListElements(app, records)
+
+	for ctx.Err() == nil {
+		clicked := app.ListenArea(ctx, replaysUi.ButtonArea)
+		switch clicked {
+		case "returnButton":
+			app.Nav.Pop(ctx)
+			return
+		default:
+			for _, record := range records {
+				if record.Path == clicked {
+					app.Nav.Push(ctx, replayScreen(record))
+					return
+				}
+			}
+		}
+	}
+}
+
+// Delays between moves when auto-playing, from slowest to fastest
+var replaySpeeds = []time.Duration{2 * time.Second, time.Second, 500 * time.Millisecond, 200 * time.Millisecond}
+
+// replayGame steps through a recorded game on the same boards that are used in a real game
+func replayScreen(record *gameRecord) Screen {
+	return Screen{Name: "replay", Enter: func(ctx context.Context, app *App) {
+		replayGame(ctx, app, record)
+	}}
+}
+
+func replayGame(ctx context.Context, app *App, record *gameRecord) {
+	start := replayState(record, 0)
+	playerBoard, opponentBoard, exitArea := board.GuiInit(app, app.boardStyle(), start.PlayerStates, start.OpponentStates)
+	controlsUi := ReplayElements(app)
+
+	g := layout.Game()
+	app.Draw(gui.NewText(g.Status.X, g.Status.Y, fitText(app.T("replay.title", record.Start.Nick, record.Start.Opponent, record.Start.Mode), g.Status.W), app.Theme().Text))
+	if result := record.result(); result != "" {
+		app.Draw(gui.NewText(g.Status.X, g.Status.Y+1, fitText(app.T("replay.result", result), g.Status.W), app.Theme().Text))
+	}
+
+	clicks := make(chan string)
+	for _, area := range []*gui.HandleArea{exitArea, controlsUi.ButtonArea} {
+		go func(area *gui.HandleArea) {
+			for {
+				clicked := app.ListenArea(ctx, area)
+				select {
+				case clicks <- clicked:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(area)
+	}
+
+	move := 0
+	speed := 1
+	playing := false
+	for {
+		drawReplayStep(app, record, move, controlsUi.Text, playerBoard, opponentBoard)
+		text := controlsUi.Text
+		app.Draw(gui.NewText(text.X, text.Y+4, fitText(app.T("replay.autoPlay", onOff(app, playing), replaySpeeds[speed]), text.W), app.Theme().Text))
+
+		var tick <-chan time.Time
+		if playing {
+			tick = time.After(replaySpeeds[speed])
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+			if move < len(record.Shots) {
+				move++
+			} else {
+				playing = false
+			}
+		case clicked := <-clicks:
+			switch clicked {
+			case "exitButton":
+				app.Nav.Pop(ctx)
+				return
+			case "firstButton":
+				move = 0
+			case "prevButton":
+				move = max(move-1, 0)
+			case "nextButton":
+				move = min(move+1, len(record.Shots))
+			case "lastButton":
+				move = len(record.Shots)
+			case "playButton":
+				playing = !playing
+				if playing && move == len(record.Shots) {
+					move = 0
+				}
+			case "slowerButton":
+				speed = max(speed-1, 0)
+			case "fasterButton":
+				speed = min(speed+1, len(replaySpeeds)-1)
+			}
+		}
+	}
+}
+
+func drawReplayStep(app *App, record *gameRecord, move int, text layout.Rect, playerBoard, opponentBoard *gui.Board) {
+	step := replayState(record, move)
+	app.SetStates(playerBoard, step.PlayerStates)
+	app.SetStates(opponentBoard, step.OpponentStates)
+
+	moveText := app.T("replay.move", move, len(record.Shots))
+	if move > 0 {
+		shot := record.Shots[move-1]
+		shooter := record.Start.Nick
+		if shot.Shooter == "opponent" {
+			shooter = record.Start.Opponent
+		}
+		moveText = app.T("replay.moveShot", move, len(record.Shots), shooter, shot.Coord, shotResult(app, shot.Result))
+	}
+	sunkText := ""
+	if len(step.SunkShip) > 0 {
+		sunkText = app.T("replay.sunk", len(step.SunkShip), strings.Join(step.SunkShip, ", "))
+	}
+
+	g := layout.Game()
+	app.Draw(gui.NewText(text.X, text.Y, fitText(moveText, text.W), app.Theme().Text))
+	app.Draw(gui.NewText(text.X, text.Y+1, fitText(sunkText, text.W), app.Theme().Text))
+	app.Draw(gui.NewText(g.PlayerInfo.X, text.Y+3, fitText(app.T("replay.yourAccuracy", accuracyText(step.PlayerHits, step.PlayerShots)), g.PlayerInfo.W-1), app.Theme().Text))
+	app.Draw(gui.NewText(g.OpponentInfo.X, text.Y+3, fitText(app.T("replay.opponentAccuracy", accuracyText(step.OpponentHits, step.OpponentShots)), g.OpponentInfo.W-1), app.Theme().Text))
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net"
 	"net/http"
 	"time"
@@ -40,24 +41,40 @@ type GameInitData struct {
 	Wpbot      bool     `json:"wpbot"`
 }
 
-// Default values
-var DefaultGameInitData = GameInitData{
-	Coords:     []string{"J10", "J6", "E10", "C7", "D1", "D2", "D3", "C2", "D7", "E7", "F1", "F2", "G1", "F5", "G5", "G8", "G9", "I4", "J4", "J8"},
-	Desc:       "default_desc",
-	Nick:       "default_nick",
-	TargetNick: "",
-	Wpbot:      false,
+// Address of the game server used when no other is given
+const DefaultServerURL = "https://go-pjatk-server.fly.dev"
+
+// APIClient sends requests to the game server
+type APIClient struct {
+	BaseURL string
+	HTTP    *http.Client
+}
+
+func NewAPIClient(baseURL string) *APIClient {
+	return &APIClient{BaseURL: baseURL, HTTP: &http.Client{Transport: loggingTransport{next: http.DefaultTransport}}}
+}
+
+// defaultGameInitData returns values used for fields that were left empty
+func defaultGameInitData() GameInitData {
+	return GameInitData{
+		Coords:     []string{"J10", "J6", "E10", "C7", "D1", "D2", "D3", "C2", "D7", "E7", "F1", "F2", "G1", "F5", "G5", "G8", "G9", "I4", "J4", "J8"},
+		Desc:       "default_desc",
+		Nick:       "default_nick",
+		TargetNick: "",
+		Wpbot:      false,
+	}
 }
 
-func InitGame(data GameInitData) (string, error) {
+func (c *APIClient) InitGame(data GameInitData) (string, error) {
+	defaults := defaultGameInitData()
 	if len(data.Coords) == 0 {
-		data.Coords = DefaultGameInitData.Coords
+		data.Coords = defaults.Coords
 	}
 	if data.Desc == "" {
-		data.Desc = DefaultGameInitData.Desc
+		data.Desc = defaults.Desc
 	}
 	if data.Nick == "" {
-		data.Nick = DefaultGameInitData.Nick
+		data.Nick = defaults.Nick
 	}
 
 	jsonData, err := json.Marshal(data)
@@ -65,7 +82,7 @@ func InitGame(data GameInitData) (string, error) {
 		return "", err
 	}
 
-	resp, err := http.Post("https://go-pjatk-server.fly.dev/api/game", "application/json", bytes.NewBuffer(jsonData))
+	resp, err := c.HTTP.Post(c.BaseURL+"/api/game", "application/json", bytes.NewBuffer(jsonData))
 	if err != nil {
 		return "", err
 	}
@@ -87,8 +104,8 @@ func InitGame(data GameInitData) (string, error) {
 }
 
 // refresh list of player in the lobby
-func GetLobbyInfo() ([]Player, string, error) {
-	resp, err := http.Get("https://go-pjatk-server.fly.dev/api/lobby")
+func (c *APIClient) GetLobbyInfo() ([]Player, string, error) {
+	resp, err := c.HTTP.Get(c.BaseURL + "/api/lobby")
 	if err != nil {
 		return nil, "", err
 	}
@@ -128,10 +145,8 @@ func GetLobbyInfo() ([]Player, string, error) {
 }
 
 // reset timer waiting in the lobby
-func RefreshLobby(authToken string) error {
-	client := &http.Client{}
-
-	req, err := http.NewRequest("GET", "https://go-pjatk-server.fly.dev/api/game/refresh", nil)
+func (c *APIClient) RefreshLobby(authToken string) error {
+	req, err := http.NewRequest("GET", c.BaseURL+"/api/game/refresh", nil)
 	if err != nil {
 		return fmt.Errorf("error creating request: %v", err)
 	}
@@ -139,7 +154,7 @@ func RefreshLobby(authToken string) error {
 	req.Header.Add("X-Auth-Token", authToken)
 
 	for i := 0; i < 10; i++ { // Retry up to 3 times
-		resp, err := client.Do(req)
+		resp, err := c.HTTP.Do(req)
 		if err != nil {
 			return fmt.Errorf("error sending request: %v", err)
 		}
@@ -156,10 +171,10 @@ func RefreshLobby(authToken string) error {
 	return fmt.Errorf("received non-OK status code: 503 after 3 retries")
 }
 
-func GetPlayerStats(nick string) (PlayerStats, error) {
-	url := fmt.Sprintf("https://go-pjatk-server.fly.dev/api/stats/%s", nick)
+func (c *APIClient) GetPlayerStats(nick string) (PlayerStats, error) {
+	url := fmt.Sprintf("%s/api/stats/%s", c.BaseURL, nick)
 
-	resp, err := http.Get(url)
+	resp, err := c.HTTP.Get(url)
 	if err != nil {
 		return PlayerStats{}, err
 	}
@@ -179,7 +194,7 @@ func GetPlayerStats(nick string) (PlayerStats, error) {
 	return playerStatsResponse.Stats, nil
 }
 
-func GetBoardInfoWithRetry(playerToken string) ([]string, error) {
+func (c *APIClient) GetBoardInfoWithRetry(playerToken string) ([]string, error) {
 	const maxRetries = 5
 	const initialDelay = time.Second
 
@@ -187,7 +202,7 @@ func GetBoardInfoWithRetry(playerToken string) ([]string, error) {
 	var err error
 
 	for retry := 0; retry < maxRetries; retry++ {
-		boardInfo, err = GetBoardInfo(playerToken)
+		boardInfo, err = c.GetBoardInfo(playerToken)
 		if err == nil {
 			return boardInfo, nil
 		}
@@ -204,29 +219,28 @@ func GetBoardInfoWithRetry(playerToken string) ([]string, error) {
 	return nil, fmt.Errorf("exceeded maximum retries: %w", err)
 }
 
-func GetBoardInfo(playerToken string) ([]string, error) {
+func (c *APIClient) GetBoardInfo(playerToken string) ([]string, error) {
 	const maxRetries = 10
 	retryDelay := 1 * time.Second
 
 	for retry := 0; retry < maxRetries; retry++ {
-		req, err := http.NewRequest("GET", "https://go-pjatk-server.fly.dev/api/game/board", nil)
+		req, err := http.NewRequest("GET", c.BaseURL+"/api/game/board", nil)
 		if err != nil {
 			return nil, err
 		}
 
 		req.Header.Set("X-Auth-Token", playerToken)
 
-		client := &http.Client{}
-		resp, err := client.Do(req)
+		resp, err := c.HTTP.Do(req)
 		if err != nil {
-			// fmt.Printf("Error on attempt %d: %v\n", retry+1, err)
+			slog.Debug("getting board info failed", "attempt", retry+1, "err", err)
 			time.Sleep(retryDelay)
 			retryDelay *= 2
 			continue
 		}
 
 		if resp.StatusCode != http.StatusOK {
-			// fmt.Printf("Error on attempt %d: status code %d\n", retry+1, resp.StatusCode)
+			slog.Debug("getting board info failed", "attempt", retry+1, "status", resp.StatusCode)
 			resp.Body.Close()
 			time.Sleep(retryDelay)
 			retryDelay *= 2
@@ -257,16 +271,15 @@ func GetBoardInfo(playerToken string) ([]string, error) {
 	return nil, errors.New("exceeded maximum number of retries")
 }
 
-func GetGameStatus(playerToken string) (string, error) {
-	req, err := http.NewRequest("GET", "https://go-pjatk-server.fly.dev/api/game", nil)
+func (c *APIClient) GetGameStatus(playerToken string) (string, error) {
+	req, err := http.NewRequest("GET", c.BaseURL+"/api/game", nil)
 	if err != nil {
 		return "", err
 	}
 
 	req.Header.Set("X-Auth-Token", playerToken)
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.HTTP.Do(req)
 	if err != nil {
 		return "", err
 	}
@@ -280,7 +293,7 @@ func GetGameStatus(playerToken string) (string, error) {
 	return string(body), nil
 }
 
-func FireAtEnemy(playerToken, coord string) (string, error) {
+func (c *APIClient) FireAtEnemy(playerToken, coord string) (string, error) {
 	data := map[string]string{
 		"coord": coord,
 	}
@@ -290,7 +303,7 @@ func FireAtEnemy(playerToken, coord string) (string, error) {
 		return "", err
 	}
 
-	req, err := http.NewRequest("POST", "https://go-pjatk-server.fly.dev/api/game/fire", bytes.NewBuffer(jsonData))
+	req, err := http.NewRequest("POST", c.BaseURL+"/api/game/fire", bytes.NewBuffer(jsonData))
 	if err != nil {
 		return "", err
 	}
@@ -298,8 +311,7 @@ func FireAtEnemy(playerToken, coord string) (string, error) {
 	req.Header.Set("X-Auth-Token", playerToken)
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.HTTP.Do(req)
 	if err != nil {
 		return "", err
 	}
@@ -313,16 +325,15 @@ func FireAtEnemy(playerToken, coord string) (string, error) {
 	return string(body), nil
 }
 
-func GetGameDescription(playerToken string) (string, error) {
-	req, err := http.NewRequest("GET", "https://go-pjatk-server.fly.dev/api/game/desc", nil)
+func (c *APIClient) GetGameDescription(playerToken string) (string, error) {
+	req, err := http.NewRequest("GET", c.BaseURL+"/api/game/desc", nil)
 	if err != nil {
 		return "", err
 	}
 
 	req.Header.Set("X-Auth-Token", playerToken)
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.HTTP.Do(req)
 	if err != nil {
 		return "", err
 	}
@@ -342,8 +353,8 @@ func GetGameDescription(playerToken string) (string, error) {
 	return gameDesc["opp_desc"], nil
 }
 
-func GetStats() ([]PlayerStats, error) {
-	resp, err := http.Get("https://go-pjatk-server.fly.dev/api/stats")
+func (c *APIClient) GetStats() ([]PlayerStats, error) {
+	resp, err := c.HTTP.Get(c.BaseURL + "/api/stats")
 	if err != nil {
 		return nil, fmt.Errorf("error getting stats: %w", err)
 	}
@@ -363,17 +374,15 @@ func GetStats() ([]PlayerStats, error) {
 	return statsResponse.Stats, nil
 }
 
-func AbandonGame(playerToken string) (string, error) {
-	client := &http.Client{}
-
-	req, err := http.NewRequest("DELETE", "https://go-pjatk-server.fly.dev/api/game/abandon", nil)
+func (c *APIClient) AbandonGame(playerToken string) (string, error) {
+	req, err := http.NewRequest("DELETE", c.BaseURL+"/api/game/abandon", nil)
 	if err != nil {
 		return "", fmt.Errorf("error creating request: %v", err)
 	}
 
 	req.Header.Add("X-Auth-Token", playerToken)
 
-	resp, err := client.Do(req)
+	resp, err := c.HTTP.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("error sending request: %v", err)
 	}
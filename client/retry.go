@@ -1,9 +1,8 @@
 package client
 
 import (
+	"log/slog"
 	"time"
-
-	gui "github.com/s25867/warships-gui/v2"
 )
 
 type ServerRequestWithPlayerStats func() (PlayerStats, error)
@@ -12,7 +11,7 @@ type ServerRequestWithPlayers func() ([]Player, string, error)
 
 type ServerRequest func() (string, error)
 
-func retryOnErrorWithPlayerStats(ui *gui.GUI, serverRequest ServerRequestWithPlayerStats) (PlayerStats, error) {
+func retryOnErrorWithPlayerStats(serverRequest ServerRequestWithPlayerStats) (PlayerStats, error) {
 	var playerStats PlayerStats
 	var err error
 
@@ -21,14 +20,14 @@ func retryOnErrorWithPlayerStats(ui *gui.GUI, serverRequest ServerRequestWithPla
 		if err == nil {
 			return playerStats, nil
 		}
-		ui.Draw(gui.NewText(1, 28, "Error: "+err.Error()+". Retrying in 2 seconds...", errorText))
+		slog.Warn("request failed, retrying", "attempt", i+1, "err", err)
 		time.Sleep(100 * time.Millisecond)
 	}
 
 	return playerStats, err
 }
 
-func retryOnErrorWithPlayers(ui *gui.GUI, serverRequest ServerRequestWithPlayers) ([]Player, string, error) {
+func retryOnErrorWithPlayers(serverRequest ServerRequestWithPlayers) ([]Player, string, error) {
 	var players []Player
 	var result string
 	var err error
@@ -38,14 +37,14 @@ func retryOnErrorWithPlayers(ui *gui.GUI, serverRequest ServerRequestWithPlayers
 		if err == nil {
 			return players, result, nil
 		}
-		ui.Draw(gui.NewText(1, 28, "Error: "+err.Error()+". Retrying in 2 seconds...", errorText))
+		slog.Warn("request failed, retrying", "attempt", i+1, "err", err)
 		time.Sleep(100 * time.Millisecond)
 	}
 
 	return players, result, err
 }
 
-func retryOnError(ui *gui.GUI, serverRequest ServerRequest) (string, error) {
+func retryOnError(serverRequest ServerRequest) (string, error) {
 	var result string
 	var err error
 
@@ -54,7 +53,7 @@ func retryOnError(ui *gui.GUI, serverRequest ServerRequest) (string, error) {
 		if err == nil {
 			return result, nil
 		}
-		ui.Draw(gui.NewText(1, 28, "Error: "+err.Error()+". Retrying...", errorText))
+		slog.Warn("request failed, retrying", "attempt", i+1, "err", err)
 		time.Sleep(100 * time.Millisecond)
 	}
 
@@ -0,0 +1,465 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gui "github.com/s25867/warships-gui/v2"
+)
+
+// How long tests wait for screens to react, some of them pause for a few seconds on purpose
+const screenTimeout = 10 * time.Second
+
+// fakeServer is the part of the game server the screens talk to
+type fakeServer struct {
+	mu      sync.Mutex
+	lobby   []Player
+	status  GameStatusResponse
+	layout  []string
+	fired   []string
+	results map[string]string // results of shots at the opponent, misses if not set
+	joined  int               // games started with POST /api/game
+}
+
+func newFakeServer(t *testing.T) (*fakeServer, *APIClient) {
+	server := &fakeServer{results: make(map[string]string)}
+	srv := httptest.NewServer(server)
+	t.Cleanup(srv.Close)
+	return server, &APIClient{BaseURL: srv.URL, HTTP: srv.Client()}
+}
+
+// update changes the state of the server while screens use it
+func (s *fakeServer) update(change func(s *fakeServer)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	change(s)
+}
+
+func (s *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var response any
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/api/game":
+		var data GameInitData
+		json.NewDecoder(r.Body).Decode(&data)
+		s.joined++
+		s.layout = data.Coords
+		s.lobby = append(s.lobby, Player{Nick: data.Nick, GameStatus: "waiting"})
+		s.status.GameStatus = "waiting"
+		w.Header().Set("x-auth-token", "token")
+		return
+	case r.URL.Path == "/api/game":
+		response = s.status
+	case r.URL.Path == "/api/lobby":
+		response = s.lobby
+		if s.lobby == nil {
+			response = []Player{}
+		}
+	case r.URL.Path == "/api/game/board":
+		response = map[string][]string{"board": s.layout}
+	case r.URL.Path == "/api/game/desc":
+		response = map[string]string{"opp_desc": "a test opponent"}
+	case r.URL.Path == "/api/game/fire":
+		var shot struct{ Coord string }
+		json.NewDecoder(r.Body).Decode(&shot)
+		s.fired = append(s.fired, shot.Coord)
+		result := s.results[shot.Coord]
+		if result == "" {
+			result = "miss"
+		}
+		response = map[string]string{"result": result}
+	case r.URL.Path == "/api/game/refresh", r.URL.Path == "/api/game/abandon":
+		return
+	case r.URL.Path == "/api/stats":
+		response = StatsResponse{Stats: []PlayerStats{{Nick: "alice", Rank: 1}}}
+	case strings.HasPrefix(r.URL.Path, "/api/stats/"):
+		response = PlayerStatsResponse{Stats: PlayerStats{Nick: strings.TrimPrefix(r.URL.Path, "/api/stats/")}}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	json.NewEncoder(w).Encode(response)
+}
+
+func TestMain(m *testing.M) {
+	// Screens log every request and retry, only failed tests are worth reading
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// Profiles, sessions and games go to a temporary directory instead of the working one
+	dir, err := os.MkdirTemp("", "statki-test")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	profilesDir = filepath.Join(dir, "profiles")
+	sessionsDir = filepath.Join(dir, "sessions")
+	gamesDir = filepath.Join(dir, "games")
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// newTestApp returns an app on a FakeUI, the profile is named after the test so
+// tests don't share profiles or sessions
+func newTestApp(t *testing.T, api *APIClient, profile Profile) (*App, *FakeUI) {
+	ui := NewFakeUI()
+	return NewApp(ui, api, profile, t.Name(), nil), ui
+}
+
+// enterScreen runs the screen until the test ends, navigation requests it makes
+// are returned by nextNav instead of being carried out
+func enterScreen(t *testing.T, app *App, screen Screen) {
+	app.NewScreen(screen.Name)
+	app.SetScreen(screen.Name)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		screen.Enter(ctx, app)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+		if screen.Exit != nil {
+			screen.Exit()
+		}
+	})
+}
+
+// nextNav waits for the screen to ask the navigator for a change
+func nextNav(t *testing.T, app *App) navRequest {
+	t.Helper()
+	select {
+	case req := <-app.Nav.requests:
+		return req
+	case <-time.After(screenTimeout):
+		t.Fatal("screen made no navigation request")
+		return navRequest{}
+	}
+}
+
+// click clicks the button once a live area on the current screen owns it,
+// screens draw their areas in the background
+func click(t *testing.T, ui *FakeUI, name string) {
+	t.Helper()
+	waitFor(t, "button "+name, func() bool { return ui.Click(name) })
+}
+
+func waitFor(t *testing.T, what string, done func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(screenTimeout)
+	for !done() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// boardWith reports whether a board on the current screen has the state at the coordinate
+func boardWith(ui *FakeUI, coord string, state gui.State) bool {
+	col, row, _ := coordToIndex(coord)
+	for _, drawable := range ui.Drawn() {
+		if b, ok := drawable.(*gui.Board); ok && ui.States(b)[col][row] == state {
+			return true
+		}
+	}
+	return false
+}
+
+func TestProfileMenu(t *testing.T) {
+	tests := []struct {
+		name   string
+		clicks []string
+		input  string
+		action navAction
+		screen string
+		saved  bool // the profile is saved to disk
+		check  func(t *testing.T, app *App)
+	}{
+		{name: "return", clicks: []string{"returnButton"}, action: navPop},
+		{name: "board editor", clicks: []string{"boardButton"}, action: navPush, screen: "editor"},
+		{name: "history", clicks: []string{"historyButton"}, action: navPush, screen: "history"},
+		{
+			name:   "random board",
+			clicks: []string{"randomBoardButton"},
+			action: navReplace,
+			screen: "profile",
+			saved:  true,
+			check: func(t *testing.T, app *App) {
+				coords := app.Profile().Coords
+				if err := ValidateLayout(coords); err != nil {
+					t.Errorf("random layout is invalid: %v", err)
+				}
+				if saved, _ := LoadProfile(app.profileName); !slices.Equal(saved.Coords, coords) {
+					t.Errorf("saved layout %v, want %v", saved.Coords, coords)
+				}
+			},
+		},
+		{
+			name:   "edit name",
+			clicks: []string{"editNameButton", "saveButton"},
+			input:  "bob",
+			action: navReplace,
+			screen: "profile",
+			saved:  true,
+			check: func(t *testing.T, app *App) {
+				if nick := app.Profile().Nick; nick != "bob" {
+					t.Errorf("nick = %q, want bob", nick)
+				}
+			},
+		},
+		{
+			name:   "cancelled edit",
+			clicks: []string{"editDescButton", "cancelButton"},
+			input:  "ignored",
+			action: navReplace,
+			screen: "profile",
+			check: func(t *testing.T, app *App) {
+				if desc := app.Profile().Desc; desc != "" {
+					t.Errorf("desc = %q, want it unchanged", desc)
+				}
+			},
+		},
+		{
+			name:   "theme",
+			clicks: []string{"themeButton"},
+			action: navReplace,
+			screen: "profile",
+			saved:  true,
+			check: func(t *testing.T, app *App) {
+				if name := app.Theme().Name; name != themeNames[1] {
+					t.Errorf("theme = %q, want %q", name, themeNames[1])
+				}
+			},
+		},
+		{
+			name:   "language",
+			clicks: []string{"languageButton"},
+			action: navReplace,
+			screen: "profile",
+			saved:  true,
+			check: func(t *testing.T, app *App) {
+				if language := app.Language(); language != languages[1] {
+					t.Errorf("language = %q, want %q", language, languages[1])
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, api := newFakeServer(t)
+			app, ui := newTestApp(t, api, Profile{Nick: "alice", Theme: themeNames[0], Language: languages[0]})
+			enterScreen(t, app, profileScreen())
+			if tt.input != "" {
+				ui.Type(tt.input)
+			}
+			for _, name := range tt.clicks {
+				click(t, ui, name)
+			}
+
+			req := nextNav(t, app)
+			if req.action != tt.action || req.screen.Name != tt.screen {
+				t.Errorf("navigation %v to %q, want %v to %q", req.action, req.screen.Name, tt.action, tt.screen)
+			}
+			if tt.check != nil {
+				tt.check(t, app)
+			}
+			if !tt.saved {
+				return
+			}
+			saved, err := LoadProfile(app.profileName)
+			if err != nil {
+				t.Fatalf("LoadProfile() error = %v", err)
+			}
+			if profile := app.Profile(); saved.Nick != profile.Nick || saved.Theme != profile.Theme || saved.Language != profile.Language {
+				t.Errorf("saved profile %+v differs from %+v", saved, profile)
+			}
+		})
+	}
+}
+
+func TestTextFieldsReturnWhenLeft(t *testing.T) {
+	for name, field := range map[string]func(*App, context.Context) string{"name": drawNameField, "desc": drawDescField} {
+		t.Run(name, func(t *testing.T) {
+			app, _ := newTestApp(t, nil, Profile{Nick: "alice"})
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			done := make(chan string)
+			go func() { done <- field(app, ctx) }()
+			select {
+			case got := <-done:
+				if got != "" {
+					t.Errorf("field returned %q after the screen was left, want nothing", got)
+				}
+			case <-time.After(screenTimeout):
+				t.Fatal("field kept listening after the screen was left")
+			}
+		})
+	}
+}
+
+func TestEditBoard(t *testing.T) {
+	tests := []struct {
+		name   string
+		cells  []string
+		clicks []string
+		want   func(coords []string) bool
+	}{
+		{
+			name:   "auto-complete and save",
+			clicks: []string{"autoButton", "saveButton"},
+			want:   func(coords []string) bool { return ValidateLayout(coords) == nil },
+		},
+		{
+			name:   "return drops changes",
+			cells:  []string{"A1", "A1"},
+			clicks: []string{"returnButton"},
+			want:   func(coords []string) bool { return len(coords) == 0 },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, api := newFakeServer(t)
+			app, ui := newTestApp(t, api, Profile{Nick: "alice"})
+			enterScreen(t, app, editorScreen())
+			for _, cell := range tt.cells {
+				ui.ClickCell(cell)
+			}
+			if len(tt.cells) > 0 {
+				waitFor(t, "ship dropped at "+tt.cells[0], func() bool { return boardWith(ui, tt.cells[0], gui.Ship) })
+			}
+			for _, name := range tt.clicks {
+				click(t, ui, name)
+			}
+
+			if req := nextNav(t, app); req.action != navPop {
+				t.Errorf("navigation %v, want the editor to be left", req.action)
+			}
+			if coords := app.Profile().Coords; !tt.want(coords) {
+				t.Errorf("profile layout %v", coords)
+			}
+		})
+	}
+}
+
+func TestPvpMenu(t *testing.T) {
+	tests := []struct {
+		name   string
+		click  string
+		action navAction
+		screen string
+	}{
+		{"return", "returnButton", navPop, ""},
+		{"join the lobby", "addYourselfButton", navReplace, "lobby"},
+		{"challenge a player", "bob", navPush, "compare"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, api := newFakeServer(t)
+			server.lobby = []Player{{Nick: "bob", GameStatus: "waiting"}}
+			app, ui := newTestApp(t, api, Profile{Nick: "alice"})
+			enterScreen(t, app, lobbyScreen(nil))
+			click(t, ui, tt.click)
+
+			req := nextNav(t, app)
+			if req.action != tt.action || req.screen.Name != tt.screen {
+				t.Errorf("navigation %v to %q, want %v to %q", req.action, req.screen.Name, tt.action, tt.screen)
+			}
+			if req.screen.Name == "lobby" {
+				// Leaving the lobby we joined stops the wait for a challenger
+				enterScreen(t, app, req.screen)
+			}
+		})
+	}
+}
+
+func TestLobbyChallenged(t *testing.T) {
+	server, api := newFakeServer(t)
+	app, ui := newTestApp(t, api, Profile{Nick: "alice"})
+	enterScreen(t, app, lobbyScreen(nil))
+	click(t, ui, "addYourselfButton")
+	req := nextNav(t, app)
+	if req.action != navReplace || req.screen.Name != "lobby" {
+		t.Fatalf("navigation %v to %q, want the lobby with our place in it", req.action, req.screen.Name)
+	}
+
+	enterScreen(t, app, req.screen)
+	waitFor(t, "waiting for a challenger", app.WaitingForChallenger)
+	server.update(func(s *fakeServer) {
+		s.lobby = nil
+		s.status = GameStatusResponse{GameStatus: "game_in_progress", Opponent: "bob"}
+	})
+	req = nextNav(t, app)
+	if req.action != navReplace || req.screen.Name != "gametoken" {
+		t.Errorf("navigation %v to %q, want the game", req.action, req.screen.Name)
+	}
+	server.update(func(s *fakeServer) {
+		if s.joined != 1 {
+			t.Errorf("joined %d games, want 1", s.joined)
+		}
+	})
+}
+
+func TestGameScreen(t *testing.T) {
+	layout := defaultGameInitData().Coords
+	server, api := newFakeServer(t)
+	server.update(func(s *fakeServer) {
+		s.layout = layout
+		s.results["B2"] = "hit"
+		s.status = GameStatusResponse{
+			GameStatus: "game_in_progress",
+			Opponent:   "bob",
+			ShouldFire: true,
+			Timer:      60,
+			OppShots:   []string{"D1", "A1"},
+		}
+	})
+	app, ui := newTestApp(t, api, Profile{Nick: "alice", Coords: layout, Game: defaultGameSettings})
+	enterScreen(t, app, gameScreen("token", app.GameData()))
+
+	ui.ClickCell("B2")
+	waitFor(t, "our hit on the opponent board", func() bool { return boardWith(ui, "B2", gui.Hit) })
+	waitFor(t, "the opponent hit on our board", func() bool { return boardWith(ui, "D1", gui.Hit) })
+	waitFor(t, "the opponent miss on our board", func() bool { return boardWith(ui, "A1", gui.Miss) })
+	session, err := loadSession(app.profileName)
+	if err != nil || session == nil || len(session.Shots) != 1 {
+		t.Fatalf("saved session %+v, error %v, want one shot to resume with", session, err)
+	}
+
+	server.update(func(s *fakeServer) {
+		s.status.GameStatus, s.status.LastGameStatus, s.status.ShouldFire = "ended", "win", false
+	})
+	if req := nextNav(t, app); req.action != navReset || req.screen.Name != "menu" {
+		t.Errorf("navigation %v to %q, want the main menu", req.action, req.screen.Name)
+	}
+	server.update(func(s *fakeServer) {
+		if !slices.Equal(s.fired, []string{"B2"}) {
+			t.Errorf("fired at %v, want [B2]", s.fired)
+		}
+	})
+	if session, _ := loadSession(app.profileName); session != nil {
+		t.Error("session of the finished game was kept")
+	}
+	record, err := loadGameRecord(session.Record)
+	if err != nil {
+		t.Fatalf("loadGameRecord() error = %v", err)
+	}
+	if record.End.Outcome != "win" || record.Start.Opponent != "bob" {
+		t.Errorf("recorded game against %q ended with %q, want a win against bob", record.Start.Opponent, record.End.Outcome)
+	}
+}
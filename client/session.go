@@ -0,0 +1,157 @@
+package client
+
+import (
+	"BomboweStatki/layout"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// Directory where games in progress are kept, one file per profile
+var sessionsDir = "sessions"
+
+// gameSession is a game in progress, saved so it can be resumed after the client exits
+type gameSession struct {
+	Token    string        `json:"token"`
+	Opponent string        `json:"opponent,omitempty"`
+	Game     GameInitData  `json:"game"`             // our nick, description and layout
+	Record   string        `json:"record,omitempty"` // file the game is recorded to
+	Shots    []sessionShot `json:"shots,omitempty"`  // our shots, the server only sends shots of the opponent
+}
+
+type sessionShot struct {
+	Coord  string `json:"coord"`
+	Result string `json:"result"`
+}
+
+func sessionPath(profileName string) string {
+	return filepath.Join(sessionsDir, safeFileName(profileName)+".json")
+}
+
+// loadSession reads the session of the profile, it returns nil if there is none
+func loadSession(profileName string) (*gameSession, error) {
+	data, err := os.ReadFile(sessionPath(profileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading session: %w", err)
+	}
+
+	var session gameSession
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("error parsing session: %w", err)
+	}
+	if session.Token == "" {
+		return nil, nil
+	}
+	return &session, nil
+}
+
+func storeSession(profileName string, session *gameSession) error {
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(sessionsDir, 0755); err != nil {
+		return fmt.Errorf("error creating sessions directory: %w", err)
+	}
+	if err := os.WriteFile(sessionPath(profileName), data, 0644); err != nil {
+		return fmt.Errorf("error saving session: %w", err)
+	}
+	return nil
+}
+
+// saveSession stores the session of the game we play, errors are shown on the screen
+func (a *App) saveSession(session *gameSession) {
+	if err := storeSession(a.profileName, session); err != nil {
+		slog.Error("saving the game session", "err", err)
+	}
+}
+
+// removeSession forgets the game in progress once it's over
+func (a *App) removeSession() {
+	if err := os.Remove(sessionPath(a.profileName)); err != nil && !os.IsNotExist(err) {
+		slog.Error("removing the game session", "err", err)
+		return
+	}
+	slog.Info("game session removed")
+}
+
+// resumeScreen offers to resume the game left in progress or to abandon it
+func resumeScreen(session *gameSession) Screen {
+	return Screen{Name: "resume", Enter: func(ctx context.Context, app *App) {
+		resumeUi := ResumeElements(app, session)
+
+		for ctx.Err() == nil {
+			switch app.ListenArea(ctx, resumeUi.ButtonArea) {
+			case "resumeButton":
+				if session == nil {
+					continue
+				}
+				if err := checkSession(app, session); err != nil {
+					// The game can't be resumed anymore, there is nothing to keep
+					slog.Warn("game can't be resumed", "err", err)
+					app.removeSession()
+					session = nil
+					statusMessage(app, layout.Message(), err.Error(), app.Theme().Error)
+					continue
+				}
+				slog.Info("resuming game", "opponent", session.Opponent, "shots", len(session.Shots))
+				app.Nav.Replace(ctx, resumedGameScreen(session))
+				return
+			case "abandonButton":
+				if session != nil {
+					_, _ = app.API.AbandonGame(session.Token)
+					app.removeSession()
+				}
+				app.Nav.Reset(ctx, mainMenuScreen())
+				return
+			}
+		}
+	}}
+}
+
+// checkSession asks the server if the game is still in progress and takes our
+// layout from the board endpoint, the layout of the profile may have changed since
+func checkSession(app *App, session *gameSession) error {
+	gameStatusResponse, err := retryOnError(func() (string, error) {
+		return app.API.GetGameStatus(session.Token)
+	})
+	if err != nil {
+		return errors.New(app.T("resume.error", err))
+	}
+	var gameStatus GameStatusResponse
+	if err := json.Unmarshal([]byte(gameStatusResponse), &gameStatus); err != nil {
+		return errors.New(app.T("resume.error", err))
+	}
+	if gameStatus.GameStatus != "game_in_progress" {
+		return errors.New(app.T("resume.ended"))
+	}
+
+	coords, err := app.API.GetBoardInfoWithRetry(session.Token)
+	if err != nil {
+		return errors.New(app.T("resume.error", err))
+	}
+	session.Game.Coords = coords
+	return nil
+}
+
+// resumedGameScreen shows the boards of a resumed game, our board is rebuilt from
+// opp_shots as in any game and the opponent board from the shots in the session
+func resumedGameScreen(session *gameSession) Screen {
+	return Screen{Name: "game" + session.Token, Enter: func(ctx context.Context, app *App) {
+		recorder, err := resumeGameRecorder(session.Record)
+		if err != nil {
+			slog.Warn("recording the game", "err", err)
+		}
+		if err := playSession(ctx, app, session, recorder); err != nil {
+			slog.Error("playing the game", "err", err)
+		}
+		leaveGame(ctx, app)
+	}}
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"slices"
+)
+
+// GameSettings are options of the game screen saved in the profile
+type GameSettings struct {
+	Warnings   []int  `json:"warnings"`     // seconds left in our turn when we are warned
+	Bell       bool   `json:"bell"`         // ring the terminal bell with warnings
+	AutoFire   bool   `json:"auto_fire"`    // fire for us when the turn is about to run out
+	AutoFireAt int    `json:"auto_fire_at"` // seconds left when auto-fire shoots
+	Strategy   string `json:"strategy"`     // bot strategy picking auto-fire shots
+	Ranked     bool   `json:"ranked"`       // ranked-style play, hints are disabled
+	Heatmap    bool   `json:"heatmap"`      // show the heatmap with hints
+}
+
+var defaultGameSettings = GameSettings{Warnings: []int{15, 5}, Bell: true, AutoFireAt: 3, Strategy: "hunt"}
+
+// Choices the game settings buttons cycle through
+var (
+	turnWarningPresets = [][]int{{15, 5}, {30, 10, 5}, {10}, {}}
+	autoFireTimes      = []int{2, 3, 5, 10}
+)
+
+// nextPreset returns the preset after current, or the first one if current isn't a preset
+func nextPreset(presets [][]int, current []int) []int {
+	for i, preset := range presets {
+		if slices.Equal(preset, current) {
+			return presets[(i+1)%len(presets)]
+		}
+	}
+	return presets[0]
+}
+
+func gameSettingsScreen() Screen {
+	return Screen{Name: "settings", Enter: gameSettingsMenu, Reflow: true}
+}
+
+func gameSettingsMenu(ctx context.Context, app *App) {
+	settingsUi := GameSettingsElements(app, app.Profile().Game)
+
+	for ctx.Err() == nil {
+		clicked := app.ListenArea(ctx, settingsUi.ButtonArea)
+		switch clicked {
+		case "returnButton":
+			app.Nav.Pop(ctx)
+			return
+		case "warningsButton", "bellButton", "autoFireButton", "autoFireAtButton", "strategyButton", "rankedButton", "heatmapButton":
+			app.updateProfile(func(profile *Profile) {
+				switch clicked {
+				case "warningsButton":
+					profile.Game.Warnings = nextPreset(turnWarningPresets, profile.Game.Warnings)
+				case "bellButton":
+					profile.Game.Bell = !profile.Game.Bell
+				case "autoFireButton":
+					profile.Game.AutoFire = !profile.Game.AutoFire
+				case "autoFireAtButton":
+					profile.Game.AutoFireAt = nextInCycle(autoFireTimes, profile.Game.AutoFireAt)
+				case "strategyButton":
+					profile.Game.Strategy = nextInCycle(StrategyNames, profile.Game.Strategy)
+				case "rankedButton":
+					profile.Game.Ranked = !profile.Game.Ranked
+				case "heatmapButton":
+					profile.Game.Heatmap = !profile.Game.Heatmap
+				}
+			})
+			app.Nav.Replace(ctx, gameSettingsScreen())
+			return
+		}
+	}
+}
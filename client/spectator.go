@@ -0,0 +1,211 @@
+package client
+
+import (
+	board "BomboweStatki/board"
+	"BomboweStatki/layout"
+	"context"
+	"time"
+
+	gui "github.com/s25867/warships-gui/v2"
+)
+
+// How long the result of a finished game stays on screen before the next one starts
+const spectatorPause = 5 * time.Second
+
+// spectatorGame is a local bot game watched on the spectator screen
+type spectatorGame struct {
+	game       *LocalGame
+	layouts    [2][]string
+	strategies [2]Strategy
+	states     [2][10][10]gui.State // fleet of player i with shots of the other player
+	hits       [2]int
+	lastShot   spectatorMessage
+}
+
+// spectatorMessage is the last move of a spectator game, it's put into words when drawn
+type spectatorMessage struct {
+	key    string // message key, empty before the first shot
+	args   []any
+	result string // result of the shot, put into words after args
+}
+
+func newSpectatorGame(names [2]string, first int) (*spectatorGame, error) {
+	g := &spectatorGame{layouts: [2][]string{RandomLayout(), RandomLayout()}}
+	for player, name := range names {
+		strategy, err := NewStrategy(name)
+		if err != nil {
+			return nil, err
+		}
+		g.strategies[player] = strategy
+		g.states[player], _, _, _ = board.Config(g.layouts[player])
+	}
+	g.game = NewLocalGame(g.layouts[0], g.layouts[1])
+	g.game.turn = first
+	return g, nil
+}
+
+// step fires one shot and returns the winner or -1 if the game goes on
+func (g *spectatorGame) step() int {
+	shooter := g.game.Turn()
+	target := 1 - shooter
+	coord := g.strategies[shooter].NextShot()
+	if coord == "" {
+		// A strategy with no shots left gives up
+		g.lastShot = spectatorMessage{key: "spectator.gaveUp", args: []any{shooter + 1}}
+		return target
+	}
+	result, err := g.game.Fire(shooter, coord)
+	if err != nil {
+		g.lastShot = spectatorMessage{key: "error.fire", args: []any{err}}
+		return target
+	}
+	g.strategies[shooter].Record(coord, result)
+	g.lastShot = spectatorMessage{"spectator.shot", []any{shooter + 1, coord}, result}
+
+	col, row, _ := coordToIndex(coord)
+	switch result {
+	case "hit":
+		g.hits[shooter]++
+		g.states[target][col][row] = gui.Hit
+	case "sunk":
+		g.hits[shooter]++
+		markStates(&g.states[target], shipContaining(mapShips(g.layouts[target]), coord), gui.Sunk)
+	default:
+		g.states[target][col][row] = gui.Miss
+	}
+	return g.game.Winner()
+}
+
+func spectatorScreen() Screen {
+	return Screen{Name: "spectator", Enter: spectate}
+}
+
+// spectate plays local bot games one after another and shows both fleets live
+func spectate(ctx context.Context, app *App) {
+	names := [2]string{"hunt", "hunt"}
+	game, err := newSpectatorGame(names, 0)
+	if err != nil {
+		statusMessage(app, layout.Message(), err.Error(), app.Theme().Error)
+		return
+	}
+	leftBoard, rightBoard, exitArea := board.GuiInit(app, app.boardStyle(), game.states[0], game.states[1])
+	controlsUi := SpectatorElements(app, names)
+
+	// The controls get a new area and listener whenever a strategy changes
+	clicks := make(chan string)
+	listen := func(ctx context.Context, area *gui.HandleArea) {
+		go func() {
+			for {
+				clicked := app.ListenArea(ctx, area)
+				select {
+				case clicks <- clicked:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	listen(ctx, exitArea)
+	controlsCtx, cancelControls := context.WithCancel(ctx)
+	defer func() { cancelControls() }()
+	listen(controlsCtx, controlsUi.ButtonArea)
+
+	speed := 2
+	playing := true
+	games, wins := 0, [2]int{}
+	winner := -1
+	for {
+		app.SetStates(leftBoard, game.states[0])
+		app.SetStates(rightBoard, game.states[1])
+		drawSpectator(app, game, controlsUi.Text, names, winner, games, wins, playing, speed)
+
+		// After a game ends the next one starts on its own, so it can run unattended
+		delay := replaySpeeds[speed]
+		if winner != -1 {
+			delay = spectatorPause
+		}
+		var tick <-chan time.Time
+		if playing {
+			tick = time.After(delay)
+		}
+
+		newGame := false
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+			if winner != -1 {
+				newGame = true
+				break
+			}
+			if winner = game.step(); winner != -1 {
+				games++
+				wins[winner]++
+			}
+		case clicked := <-clicks:
+			switch clicked {
+			case "exitButton":
+				app.Nav.Pop(ctx)
+				return
+			case "playButton":
+				playing = !playing
+			case "slowerButton":
+				speed = max(speed-1, 0)
+			case "fasterButton":
+				speed = min(speed+1, len(replaySpeeds)-1)
+			case "newGameButton":
+				newGame = true
+			case "strategyAButton", "strategyBButton":
+				player := 0
+				if clicked == "strategyBButton" {
+					player = 1
+				}
+				names[player] = nextInCycle(StrategyNames, names[player])
+				cancelControls()
+				for _, drawable := range controlsUi.Drawable {
+					app.Remove(drawable)
+				}
+				controlsUi = SpectatorElements(app, names)
+				controlsCtx, cancel := context.WithCancel(ctx)
+				cancelControls = cancel
+				listen(controlsCtx, controlsUi.ButtonArea)
+				newGame = true
+			}
+		}
+
+		if newGame {
+			// Players take turns starting
+			if next, err := newSpectatorGame(names, (games+1)%2); err == nil {
+				game = next
+			}
+			winner = -1
+		}
+	}
+}
+
+func drawSpectator(app *App, game *spectatorGame, text layout.Rect, names [2]string, winner, games int, wins [2]int, playing bool, speed int) {
+	g := layout.Game()
+	// Both lines share the first status row, each starts above its board when they are side by side
+	sides := g.Status.Columns(0, 0)
+	if g.Player.X != g.Opponent.X {
+		sides = g.Status.Columns(g.Opponent.X-g.Status.X, 0)
+	}
+	for player, side := range sides {
+		shots := game.game.Shots(player)
+		line := app.T("spectator.player", player+1, names[player], shots, accuracyText(game.hits[player], shots), wins[player])
+		app.Draw(gui.NewText(side.X, side.Y, fitText(line, side.W-1), app.Theme().Text))
+	}
+	status := ""
+	if game.lastShot.key != "" {
+		args := game.lastShot.args
+		if game.lastShot.result != "" {
+			args = append(args[:len(args):len(args)], shotResult(app, game.lastShot.result))
+		}
+		status = app.T(game.lastShot.key, args...)
+	}
+	if winner != -1 {
+		status = app.T("spectator.won", winner+1, spectatorPause)
+	}
+	app.Draw(gui.NewText(g.Status.X, g.Status.Y+1, fitText(status, g.Status.W), app.Theme().Text))
+	app.Draw(gui.NewText(text.X, text.Y, fitText(app.T("spectator.games", games, onOff(app, playing), replaySpeeds[speed]), text.W), app.Theme().Text))
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Strategy picks bot shots based on the results of previous ones
+type Strategy interface {
+	NextShot() string // empty string means there is nothing left to shoot at
+	Record(coord, result string)
+}
+
+// Names of strategies that can be created with NewStrategy
+var StrategyNames = []string{"hunt", "parity", "random"}
+
+func NewStrategy(name string) (Strategy, error) {
+	switch name {
+	case "hunt":
+		return &gridStrategy{target: true}, nil
+	case "parity":
+		return &gridStrategy{target: true, parity: true}, nil
+	case "random":
+		return &gridStrategy{}, nil
+	default:
+		return nil, fmt.Errorf("unknown strategy %q, available: %v", name, StrategyNames)
+	}
+}
+
+// gridStrategy shoots at random unknown cells. With target enabled it finishes off
+// hit ships first, with parity it hunts on a checkerboard pattern.
+type gridStrategy struct {
+	grid   targetGrid
+	target bool
+	parity bool
+}
+
+func (s *gridStrategy) NextShot() string {
+	if s.target {
+		if cells := s.grid.targetCells(); len(cells) > 0 {
+			cell := cells[rand.Intn(len(cells))]
+			return indexToCoord(cell[0], cell[1])
+		}
+	}
+
+	cells := s.grid.unknownCells()
+	if s.parity {
+		even := make([][2]int, 0, len(cells))
+		for _, cell := range cells {
+			if (cell[0]+cell[1])%2 == 0 {
+				even = append(even, cell)
+			}
+		}
+		if len(even) > 0 {
+			cells = even
+		}
+	}
+	if len(cells) == 0 {
+		return ""
+	}
+	cell := cells[rand.Intn(len(cells))]
+	return indexToCoord(cell[0], cell[1])
+}
+
+func (s *gridStrategy) Record(coord, result string) {
+	s.grid.record(coord, result)
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	board "BomboweStatki/board"
+	"fmt"
+	"strings"
+
+	gui "github.com/s25867/warships-gui/v2"
+)
+
+// Characters used to draw board states as plain text
+var stateChars = map[gui.State]byte{
+	gui.Empty: '.',
+	gui.Ship:  '#',
+	gui.Hit:   'X',
+	gui.Miss:  'o',
+	gui.Sunk:  '*',
+}
+
+// renderBoard draws the board as lines of text, columns are letters and rows are numbers
+func renderBoard(states [10][10]gui.State) []string {
+	lines := []string{"   A B C D E F G H I J"}
+	for row := 0; row < 10; row++ {
+		line := fmt.Sprintf("%2d ", row+1)
+		for col := 0; col < 10; col++ {
+			char, ok := stateChars[states[col][row]]
+			if !ok {
+				char = '?'
+			}
+			line += string(char) + " "
+		}
+		lines = append(lines, strings.TrimRight(line, " "))
+	}
+	return lines
+}
+
+// RenderLayout draws a ship layout as plain text
+func RenderLayout(coords []string) (string, error) {
+	states, _, _, err := board.Config(coords)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(renderBoard(states), "\n") + "\n", nil
+}
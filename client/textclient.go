@@ -0,0 +1,230 @@
+package client
+
+import (
+	board "BomboweStatki/board"
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	gui "github.com/s25867/warships-gui/v2"
+)
+
+// textGame is the state of a game played in the plain text client
+type textGame struct {
+	api            *APIClient
+	in             *bufio.Scanner
+	out            io.Writer
+	playerToken    string
+	nick           string
+	opponent       string
+	layout         []string
+	playerStates   [10][10]gui.State
+	opponentStates [10][10]gui.State
+	shots          []string // our shots
+	hits           []string // our shots that hit
+	oppShots       int      // opponent shots already shown
+	recorder       *gameRecorder
+}
+
+// PlayText plays a whole game reading shots from in and printing boards to out,
+// it doesn't need the terminal UI so it works in any shell or from a script
+func PlayText(ctx context.Context, api *APIClient, in io.Reader, out io.Writer, gameData GameInitData) error {
+	game := &textGame{api: api, in: bufio.NewScanner(in), out: out, nick: gameData.Nick}
+	if game.nick == "" {
+		game.nick = defaultGameInitData().Nick
+	}
+
+	var err error
+	game.playerToken, err = api.InitGame(gameData)
+	if err != nil {
+		return fmt.Errorf("error initializing game: %w", err)
+	}
+
+	fmt.Fprintln(out, "Waiting for the game to start...")
+	if err := api.WaitForGame(ctx, game.playerToken); err != nil {
+		return err
+	}
+
+	game.layout, err = api.GetBoardInfoWithRetry(game.playerToken)
+	if err != nil {
+		return fmt.Errorf("error getting board info: %w", err)
+	}
+	game.playerStates, game.opponentStates, _, _ = board.Config(game.layout)
+
+	gameStatus, err := api.fetchGameStatus(ctx, game.playerToken)
+	if err != nil {
+		return err
+	}
+	game.opponent = gameStatus.Opponent
+	fmt.Fprintf(out, "Game started! Your opponent is %s\n", game.opponent)
+	oppDesc, err := api.GetGameDescription(game.playerToken)
+	if err == nil && oppDesc != "" {
+		fmt.Fprintf(out, "Opponent description: %s\n", oppDesc)
+	}
+
+	game.recorder, err = newGameRecorder(GameEvent{
+		Nick:     game.nick,
+		Desc:     gameData.Desc,
+		Coords:   game.layout,
+		Opponent: game.opponent,
+		OppDesc:  oppDesc,
+		Mode:     gameMode(gameData, game.opponent),
+	})
+	if err != nil {
+		fmt.Fprintln(out, "Game won't be recorded:", err)
+	}
+
+	err = game.loop(ctx)
+	reason := "error"
+	if ctx.Err() != nil {
+		reason = "exit"
+	}
+	game.recorder.close(reason)
+	return err
+}
+
+func (g *textGame) loop(ctx context.Context) error {
+	waiting := false
+	for {
+		gameStatus, err := g.api.fetchGameStatus(ctx, g.playerToken)
+		if err != nil {
+			return err
+		}
+		g.applyOpponentShots(gameStatus.OppShots)
+
+		if gameStatus.GameStatus == "ended" {
+			g.recorder.end(gameStatus.LastGameStatus, "finished")
+			g.printBoards()
+			if gameStatus.LastGameStatus == "win" {
+				fmt.Fprintln(g.out, "Congratulations You Win")
+			} else {
+				fmt.Fprintln(g.out, "Unfortunately You Lose")
+			}
+			return nil
+		}
+
+		if !gameStatus.ShouldFire {
+			if !waiting {
+				fmt.Fprintln(g.out, "Waiting for the opponent to fire...")
+				waiting = true
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(500 * time.Millisecond):
+			}
+			continue
+		}
+		waiting = false
+
+		g.recorder.timer(gameStatus.Timer)
+		g.printBoards()
+		fmt.Fprintf(g.out, "Timer: %d\n", gameStatus.Timer)
+		coord, err := g.readShot()
+		if err != nil {
+			if _, abandonErr := g.api.AbandonGame(g.playerToken); abandonErr != nil {
+				fmt.Fprintln(g.out, "Error leaving game:", abandonErr)
+			}
+			g.recorder.end("lose", "abandoned")
+			return err
+		}
+
+		result, err := g.api.FireWithResult(g.playerToken, coord)
+		if err != nil {
+			fmt.Fprintln(g.out, "Error firing at enemy:", err)
+			continue
+		}
+		g.applyShot(coord, result)
+		g.recorder.shot("player", coord, result)
+		fmt.Fprintf(g.out, "You fired at %s: %s\n", coord, result)
+	}
+}
+
+// readShot asks for coordinates until valid ones are given, q leaves the game
+func (g *textGame) readShot() (string, error) {
+	for {
+		fmt.Fprint(g.out, "Your shot (e.g. B7, q to leave): ")
+		if !g.in.Scan() {
+			return "", errors.New("input closed, leaving game")
+		}
+		coord := strings.ToUpper(strings.TrimSpace(g.in.Text()))
+		if coord == "Q" {
+			return "", errors.New("left the game")
+		}
+		if _, _, err := coordToIndex(coord); err != nil {
+			fmt.Fprintln(g.out, err)
+			continue
+		}
+		if findIndex(g.shots, coord) != -1 {
+			fmt.Fprintln(g.out, "You have already fired at this coordinate")
+			continue
+		}
+		return coord, nil
+	}
+}
+
+func (g *textGame) applyShot(coord, result string) {
+	col, row, _ := coordToIndex(coord)
+	g.shots = append(g.shots, coord)
+	switch result {
+	case "hit":
+		g.hits = append(g.hits, coord)
+		g.opponentStates[col][row] = gui.Hit
+	case "sunk":
+		g.hits = append(g.hits, coord)
+		markStates(&g.opponentStates, shipContaining(mapShips(g.hits), coord), gui.Sunk)
+	default:
+		g.opponentStates[col][row] = gui.Miss
+	}
+}
+
+// applyOpponentShots marks new opponent shots on our board and prints them
+func (g *textGame) applyOpponentShots(oppShots []string) {
+	ships := mapShips(g.layout)
+	for i, coord := range oppShots {
+		if i < g.oppShots {
+			continue
+		}
+		col, row, err := coordToIndex(coord)
+		if err != nil {
+			continue
+		}
+		result := "miss"
+		if findIndex(g.layout, coord) == -1 {
+			g.playerStates[col][row] = gui.Miss
+		} else {
+			result = "hit"
+			g.playerStates[col][row] = gui.Hit
+			ship := shipContaining(ships, coord)
+			sunk := true
+			for _, shipCoord := range ship {
+				shipCol, shipRow, _ := coordToIndex(shipCoord)
+				if g.playerStates[shipCol][shipRow] != gui.Hit && g.playerStates[shipCol][shipRow] != gui.Sunk {
+					sunk = false
+				}
+			}
+			if sunk {
+				result = "sunk"
+				markStates(&g.playerStates, ship, gui.Sunk)
+			}
+		}
+		g.recorder.opponentShot(i, coord, result)
+		fmt.Fprintf(g.out, "%s fired at %s: %s\n", g.opponent, coord, result)
+	}
+	g.oppShots = max(g.oppShots, len(oppShots))
+}
+
+// printBoards prints our board and the opponent board side by side
+func (g *textGame) printBoards() {
+	left := renderBoard(g.playerStates)
+	right := renderBoard(g.opponentStates)
+	fmt.Fprintf(g.out, "\n%-26s%s\n", "You: "+g.nick, "Opponent: "+g.opponent)
+	for i := range left {
+		fmt.Fprintf(g.out, "%-26s%s\n", left[i], right[i])
+	}
+	fmt.Fprintf(g.out, "Shots: %d, accuracy: %s\n", len(g.shots), accuracyText(len(g.hits), len(g.shots)))
+}
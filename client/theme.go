@@ -0,0 +1,157 @@
+package client
+
+import (
+	board "BomboweStatki/board"
+
+	gui "github.com/s25867/warships-gui/v2"
+)
+
+// Theme holds colours of named roles used by all screens, so screens don't pick gui colours themselves
+type Theme struct {
+	Name string
+
+	Background gui.Color
+	Foreground gui.Color
+	ButtonText gui.Color
+	Primary    gui.Color // regular actions
+	Success    gui.Color // confirming and starting games, wins
+	Danger     gui.Color // leaving and cancelling, errors
+	Highlight  gui.Color // secondary actions, newcomers and our own entries
+	Muted      gui.Color // entries that can't be used right now
+
+	// Board colours
+	BoardEmpty gui.Color
+	BoardShip  gui.Color
+	BoardHit   gui.Color
+	BoardMiss  gui.Color
+	BoardSunk  gui.Color
+
+	// Text styles built from the colours above
+	Text          *gui.TextConfig
+	Error         *gui.TextConfig
+	SuccessText   *gui.TextConfig
+	HighlightText *gui.TextConfig
+	MutedText     *gui.TextConfig
+}
+
+// Names of built-in themes, the first one is the default
+var themeNames = []string{"dark", "light", "contrast"}
+
+// ThemeByName returns a built-in theme, unknown names give the default one
+func ThemeByName(name string) *Theme {
+	var theme Theme
+	switch name {
+	case "light":
+		theme = Theme{
+			Background: gui.White,
+			Foreground: gui.Black,
+			ButtonText: gui.White,
+			Primary:    gui.NewColor(0, 90, 200),
+			Success:    gui.NewColor(0, 130, 60),
+			Danger:     gui.NewColor(190, 30, 30),
+			Highlight:  gui.NewColor(190, 120, 0),
+			Muted:      gui.NewColor(120, 120, 120),
+			BoardEmpty: gui.NewColor(210, 225, 240),
+			BoardShip:  gui.NewColor(90, 90, 90),
+			BoardHit:   gui.NewColor(230, 80, 30),
+			BoardMiss:  gui.NewColor(160, 160, 160),
+			BoardSunk:  gui.NewColor(140, 0, 0),
+		}
+	case "contrast":
+		// Okabe-Ito colours, told apart with any kind of colour blindness
+		theme = Theme{
+			Background: gui.Black,
+			Foreground: gui.White,
+			ButtonText: gui.Black,
+			Primary:    gui.NewColor(86, 180, 233),
+			Success:    gui.NewColor(0, 158, 115),
+			Danger:     gui.NewColor(213, 94, 0),
+			Highlight:  gui.NewColor(240, 228, 66),
+			Muted:      gui.NewColor(170, 170, 170),
+			BoardEmpty: gui.Black,
+			BoardShip:  gui.White,
+			BoardHit:   gui.NewColor(230, 159, 0),
+			BoardMiss:  gui.NewColor(0, 114, 178),
+			BoardSunk:  gui.NewColor(213, 94, 0),
+		}
+	default:
+		theme = Theme{
+			Name:       themeNames[0],
+			Background: gui.Black,
+			Foreground: gui.White,
+			ButtonText: gui.Black,
+			Primary:    gui.Blue,
+			Success:    gui.Green,
+			Danger:     gui.Red,
+			Highlight:  gui.Yellow,
+			Muted:      gui.White,
+			BoardEmpty: gui.NewColor(0, 0, 120),
+			BoardShip:  gui.Green,
+			BoardHit:   gui.Red,
+			BoardMiss:  gui.NewColor(128, 128, 128),
+			BoardSunk:  gui.NewColor(128, 0, 0),
+		}
+	}
+	if theme.Name == "" {
+		theme.Name = name
+	}
+
+	theme.Text = theme.textConfig(theme.Foreground)
+	theme.Error = theme.textConfig(theme.Danger)
+	theme.SuccessText = theme.textConfig(theme.Success)
+	theme.HighlightText = theme.textConfig(theme.Highlight)
+	theme.MutedText = theme.textConfig(theme.Muted)
+	return &theme
+}
+
+func (t *Theme) textConfig(color gui.Color) *gui.TextConfig {
+	config := gui.NewTextConfig()
+	config.FgColor = color
+	config.BgColor = t.Background
+	return config
+}
+
+// BoardConfig returns a board config with board colours of the theme
+func (t *Theme) BoardConfig() *gui.BoardConfig {
+	config := gui.NewBoardConfig()
+	config.RulerColor = t.Foreground
+	config.TextColor = t.Foreground
+	config.EmptyColor = t.BoardEmpty
+	config.ShipColor = t.BoardShip
+	config.HitColor = t.BoardHit
+	config.MissColor = t.BoardMiss
+	config.SunkColor = t.BoardSunk
+	return config
+}
+
+// HeatmapConfig returns a board config showing hint heat levels, from Empty for
+// cells that can't hold a ship through Miss, Ship and Hit up to Sunk for the hint
+func (t *Theme) HeatmapConfig() *gui.BoardConfig {
+	config := t.BoardConfig()
+	config.EmptyColor = t.Background
+	config.MissColor = t.Muted
+	config.ShipColor = t.Primary
+	config.HitColor = t.Highlight
+	config.SunkColor = t.Danger
+	config.EmptyChar, config.MissChar, config.ShipChar, config.HitChar, config.SunkChar = ' ', '.', '+', '#', '@'
+	return config
+}
+
+// HintConfig returns a board config drawn over the board we shoot at with the hint as the only ship
+func (t *Theme) HintConfig() *gui.BoardConfig {
+	config := t.BoardConfig()
+	config.ShipColor = t.Highlight
+	return config
+}
+
+// BoardStyle returns colours used by the game screen
+func (t *Theme) BoardStyle() board.Style {
+	return board.Style{Board: t.BoardConfig(), Exit: t.Danger, ExitText: t.ButtonText}
+}
+
+// boardStyle returns the style of the game screen in the theme and language of the app
+func (a *App) boardStyle() board.Style {
+	style := a.Theme().BoardStyle()
+	style.ExitLabel = a.T("common.exit")
+	return style
+}
@@ -0,0 +1,36 @@
+package client
+
+// turnTimer notices when the timer of our move passes one of the warning thresholds
+type turnTimer struct {
+	warnings []int
+	last     int // timer at the previous update in our turn, 0 outside of our turn
+}
+
+// update returns the threshold the timer passed since the previous update, or 0 if none
+func (t *turnTimer) update(ourTurn bool, timer int) int {
+	if !ourTurn {
+		t.last = 0
+		return 0
+	}
+	passed := 0
+	for _, threshold := range t.warnings {
+		if timer <= threshold && (t.last == 0 || t.last > threshold) && (passed == 0 || threshold < passed) {
+			passed = threshold
+		}
+	}
+	t.last = timer
+	return passed
+}
+
+// autoFireShot asks the strategy for a shot at a cell we know nothing about, known
+// cells it suggests are recorded as misses so it moves on to other cells
+func autoFireShot(strategy Strategy, known *targetGrid) string {
+	for i := 0; i < 100; i++ {
+		coord := strategy.NextShot()
+		if coord == "" || !known.known(coord) {
+			return coord
+		}
+		strategy.Record(coord, "miss")
+	}
+	return ""
+}
@@ -0,0 +1,46 @@
+package client
+
+import "testing"
+
+func TestTurnTimerUpdate(t *testing.T) {
+	type update struct {
+		ourTurn bool
+		timer   int
+		want    int
+	}
+	tests := []struct {
+		name    string
+		updates []update
+	}{
+		{"each threshold once", []update{{true, 30, 0}, {true, 15, 15}, {true, 14, 0}, {true, 5, 5}, {true, 4, 0}}},
+		{"skipped threshold reports the lowest", []update{{true, 30, 0}, {true, 4, 5}}},
+		{"first update below a threshold", []update{{true, 10, 15}}},
+		{"not our turn", []update{{false, 10, 0}, {false, 3, 0}}},
+		{"new turn warns again", []update{{true, 14, 15}, {false, 20, 0}, {true, 14, 15}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timer := &turnTimer{warnings: []int{15, 5}}
+			for i, u := range tt.updates {
+				if got := timer.update(u.ourTurn, u.timer); got != u.want {
+					t.Errorf("update %d (%v, %d) = %d, want %d", i, u.ourTurn, u.timer, got, u.want)
+				}
+			}
+		})
+	}
+}
+
+func TestAutoFireShotSkipsKnownCells(t *testing.T) {
+	var known targetGrid
+	for col := 0; col < 10; col++ {
+		for row := 0; row < 10; row++ {
+			if col != 4 || row != 6 {
+				known[col][row] = cellInferredEmpty
+			}
+		}
+	}
+	strategy, _ := NewStrategy("random")
+	if got := autoFireShot(strategy, &known); got != "E7" {
+		t.Errorf("autoFireShot() = %q, want E7", got)
+	}
+}
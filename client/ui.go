@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	gui "github.com/s25867/warships-gui/v2"
+)
+
+// UI is everything the client logic needs from the terminal interface. Listening for
+// clicks and input goes through it too, so menus and game loops can run against FakeUI.
+type UI interface {
+	Draw(d gui.Drawable)
+	Remove(d gui.Drawable)
+	NewScreen(name string)
+	SetScreen(name string)
+	SetStates(b *gui.Board, states [10][10]gui.State)
+	// NewHandleArea creates an area listening to clicks on the named buttons
+	NewHandleArea(buttons map[string]gui.Spatial) *gui.HandleArea
+	// ListenArea blocks until one of the buttons of the area is clicked and returns its name
+	ListenArea(ctx context.Context, area *gui.HandleArea) string
+	// ListenBoard blocks until a cell of the board is clicked and returns its coordinate
+	ListenBoard(ctx context.Context, b *gui.Board) string
+	ReadInput(input *gui.TextInput) string
+	// Bell rings the terminal bell
+	Bell()
+}
+
+// guiUI is the UI drawn by warships-gui
+type guiUI struct {
+	gui *gui.GUI
+}
+
+func NewUI(g *gui.GUI) UI {
+	return &guiUI{gui: g}
+}
+
+func (u *guiUI) Draw(d gui.Drawable) {
+	u.gui.Draw(d)
+}
+
+func (u *guiUI) Remove(d gui.Drawable) {
+	u.gui.Remove(d)
+}
+
+func (u *guiUI) NewScreen(name string) {
+	u.gui.NewScreen(name)
+}
+
+func (u *guiUI) SetScreen(name string) {
+	u.gui.SetScreen(name)
+}
+
+func (u *guiUI) SetStates(b *gui.Board, states [10][10]gui.State) {
+	b.SetStates(states)
+}
+
+func (u *guiUI) NewHandleArea(buttons map[string]gui.Spatial) *gui.HandleArea {
+	return gui.NewHandleArea(buttons)
+}
+
+func (u *guiUI) ListenArea(ctx context.Context, area *gui.HandleArea) string {
+	return area.Listen(ctx)
+}
+
+func (u *guiUI) ListenBoard(ctx context.Context, b *gui.Board) string {
+	return b.Listen(ctx)
+}
+
+func (u *guiUI) ReadInput(input *gui.TextInput) string {
+	return input.GetContent()
+}
+
+func (u *guiUI) Bell() {
+	fmt.Fprint(os.Stdout, "\a")
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"BomboweStatki/client"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// printOutput prints v as JSON or calls table to print it as a table
+func printOutput(opts options, v any, table func(w *tabwriter.Writer)) error {
+	if opts.output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	table(w)
+	return w.Flush()
+}
+
+func textCommand(opts options, args []string) error {
+	flags := flag.NewFlagSet("text", flag.ContinueOnError)
+	target := flags.String("target", "", "nick of the player to challenge, joins the lobby if empty")
+	wpbot := flags.Bool("wpbot", false, "play against the server bot")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	gameData := opts.profile.GameData()
+	gameData.TargetNick = *target
+	gameData.Wpbot = *wpbot
+	return client.PlayText(context.Background(), opts.api, os.Stdin, os.Stdout, gameData)
+}
+
+func lobbyCommand(opts options, args []string) error {
+	players, _, err := opts.api.GetLobbyInfo()
+	if err != nil {
+		return fmt.Errorf("error getting lobby info: %w", err)
+	}
+	return printOutput(opts, players, func(w *tabwriter.Writer) {
+		fmt.Fprintln(w, "NICK\tSTATUS")
+		for _, player := range players {
+			fmt.Fprintf(w, "%s\t%s\n", player.Nick, player.GameStatus)
+		}
+	})
+}
+
+func statsCommand(opts options, args []string) error {
+	var stats []client.PlayerStats
+	if len(args) > 0 {
+		playerStats, err := opts.api.GetPlayerStats(args[0])
+		if err != nil {
+			return fmt.Errorf("error getting player stats: %w", err)
+		}
+		stats = append(stats, playerStats)
+	} else {
+		var err error
+		stats, err = opts.api.GetStats()
+		if err != nil {
+			return err
+		}
+		sort.Slice(stats, func(i, j int) bool {
+			return stats[i].Rank < stats[j].Rank
+		})
+	}
+
+	var out any = stats
+	if len(args) > 0 {
+		out = stats[0]
+	}
+	return printOutput(opts, out, func(w *tabwriter.Writer) {
+		fmt.Fprintln(w, "RANK\tNICK\tPOINTS\tWINS\tGAMES")
+		for _, player := range stats {
+			fmt.Fprintf(w, "%d\t%s\t%d\t%d\t%d\n", player.Rank, player.Nick, player.Points, player.Wins, player.Games)
+		}
+	})
+}
+
+func layoutCommand(opts options, args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: statki layout random|validate|show [coords...]")
+	}
+	// Coordinates can be given as arguments, the profile layout is used otherwise
+	coords := opts.profile.Coords
+	if len(args) > 1 {
+		coords = splitCoords(args[1:])
+	}
+
+	switch args[0] {
+	case "random":
+		coords = client.RandomLayout()
+		rendered, err := client.RenderLayout(coords)
+		if err != nil {
+			return err
+		}
+		return printOutput(opts, coords, func(w *tabwriter.Writer) {
+			fmt.Fprintln(w, strings.Join(coords, " "))
+			fmt.Fprint(w, rendered)
+		})
+	case "validate":
+		err := client.ValidateLayout(coords)
+		result := map[string]any{"coords": coords, "valid": err == nil}
+		if err != nil {
+			result["error"] = err.Error()
+		}
+		if printErr := printOutput(opts, result, func(w *tabwriter.Writer) {
+			if err == nil {
+				fmt.Fprintln(w, "Layout is valid")
+			}
+		}); printErr != nil {
+			return printErr
+		}
+		return err
+	case "show":
+		if err := client.ValidateLayout(coords); err != nil {
+			return err
+		}
+		rendered, err := client.RenderLayout(coords)
+		if err != nil {
+			return err
+		}
+		return printOutput(opts, coords, func(w *tabwriter.Writer) {
+			fmt.Fprint(w, rendered)
+		})
+	default:
+		return fmt.Errorf("unknown layout command %q", args[0])
+	}
+}
+
+// splitCoords accepts coordinates separated by spaces or commas
+func splitCoords(args []string) []string {
+	coords := make([]string, 0, 20)
+	for _, arg := range args {
+		for _, coord := range strings.FieldsFunc(arg, func(r rune) bool { return r == ',' || r == ' ' }) {
+			coords = append(coords, strings.ToUpper(coord))
+		}
+	}
+	return coords
+}
+
+func botCommand(opts options, args []string) error {
+	flags := flag.NewFlagSet("bot", flag.ContinueOnError)
+	strategyName := flags.String("strategy", "hunt", "bot strategy: "+strings.Join(client.StrategyNames, ", "))
+	target := flags.String("target", "", "nick of the player to challenge, joins the lobby if empty")
+	wpbot := flags.Bool("wpbot", false, "play against the server bot")
+	quiet := flags.Bool("quiet", false, "don't print every shot")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	strategy, err := client.NewStrategy(*strategyName)
+	if err != nil {
+		return err
+	}
+
+	gameData := opts.profile.GameData()
+	gameData.TargetNick = *target
+	gameData.Wpbot = *wpbot
+	playerToken, err := opts.api.InitGame(gameData)
+	if err != nil {
+		return fmt.Errorf("error initializing game: %w", err)
+	}
+
+	// Progress goes to stderr, stdout only gets the result so -output json stays parseable
+	ctx := context.Background()
+	fmt.Fprintln(os.Stderr, "Waiting for the game to start...")
+	if err := opts.api.WaitForGame(ctx, playerToken); err != nil {
+		return err
+	}
+
+	logf := func(format string, args ...any) {
+		if !*quiet {
+			fmt.Fprintf(os.Stderr, format+"\n", args...)
+		}
+	}
+	outcome, err := opts.api.RunBot(ctx, playerToken, strategy, logf)
+	if err != nil {
+		return err
+	}
+	return printOutput(opts, map[string]string{"strategy": *strategyName, "outcome": outcome}, func(w *tabwriter.Writer) {
+		fmt.Fprintln(w, "Game ended:", outcome)
+	})
+}
+
+func simulateCommand(opts options, args []string) error {
+	flags := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	first := flags.String("a", "hunt", "strategy of the first bot")
+	second := flags.String("b", "random", "strategy of the second bot")
+	games := flags.Int("games", 100, "number of games to play")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	result, err := client.Simulate(*first, *second, *games)
+	if err != nil {
+		return err
+	}
+	return printOutput(opts, result, func(w *tabwriter.Writer) {
+		fmt.Fprintln(w, "STRATEGY\tWINS\tAVG SHOTS TO WIN")
+		for player, name := range result.Strategies {
+			fmt.Fprintf(w, "%s\t%d/%d\t%.1f\n", name, result.Wins[player], result.Games, result.AvgShotsToWin(player))
+		}
+		fmt.Fprintf(w, "\nShots per game: %.1f\n", result.ShotsPerGame)
+	})
+}
@@ -4,12 +4,14 @@ go 1.22.1
 
 replace github.com/s25867/warships-gui/v2 => ../warships-gui
 
-require github.com/s25867/warships-gui/v2 v2.0.4
+require (
+	github.com/nsf/termbox-go v1.1.1
+	github.com/s25867/warships-gui/v2 v2.0.4
+)
 
 require (
 	github.com/google/uuid v1.3.0 // indirect
 	github.com/grupawp/termloop v0.0.0-20230531144437-277a1cbf4c14 // indirect
 	github.com/mattn/go-runewidth v0.0.14 // indirect
-	github.com/nsf/termbox-go v1.1.1 // indirect
 	github.com/rivo/uniseg v0.4.4 // indirect
 )
@@ -0,0 +1,269 @@
+package layout
+
+import (
+	"github.com/nsf/termbox-go"
+)
+
+// Terminal size assumed before the terminal is set up
+const (
+	DefaultWidth  = 120
+	DefaultHeight = 30
+)
+
+// Space taken by a gui.Board including its rulers
+const (
+	BoardWidth  = 45
+	BoardHeight = 12
+)
+
+// Size returns the size of the terminal in cells
+func Size() (int, int) {
+	w, h := termbox.Size()
+	if w <= 0 || h <= 0 {
+		return DefaultWidth, DefaultHeight
+	}
+	return w, h
+}
+
+// Rect is an area of the screen in cells
+type Rect struct {
+	X, Y, W, H int
+}
+
+// Box is the size of a widget to place
+type Box struct {
+	W, H int
+}
+
+// Screen returns the whole terminal
+func Screen() Rect {
+	w, h := Size()
+	return Rect{W: w, H: h}
+}
+
+// Rows at the bottom of the terminal kept for the log panel
+const LogRows = 3
+
+// Content returns the terminal without the log panel rows, screens are laid out in it
+func Content() Rect {
+	screen := Screen()
+	screen.H = max(screen.H-LogRows, 0)
+	return screen
+}
+
+// Log returns the rows of the log panel at the bottom of the terminal
+func Log() Rect {
+	screen := Screen()
+	return screen.Below(Content().Bottom())
+}
+
+func (r Rect) Right() int  { return r.X + r.W }
+func (r Rect) Bottom() int { return r.Y + r.H }
+
+// Pad shrinks the area by n cells on every side
+func (r Rect) Pad(n int) Rect {
+	return Rect{X: r.X + n, Y: r.Y + n, W: max(r.W-2*n, 0), H: max(r.H-2*n, 0)}
+}
+
+// Below returns the part of the area under the given row
+func (r Rect) Below(y int) Rect {
+	if y < r.Y {
+		return r
+	}
+	return Rect{X: r.X, Y: y, W: r.W, H: max(r.Bottom()-y, 0)}
+}
+
+// Rows splits the area into rows of the given heights, rows of height 0 share the space left
+func (r Rect) Rows(heights ...int) []Rect {
+	sizes := split(r.H, heights)
+	rows := make([]Rect, len(sizes))
+	y := r.Y
+	for i, h := range sizes {
+		rows[i] = Rect{X: r.X, Y: y, W: r.W, H: h}
+		y += h
+	}
+	return rows
+}
+
+// Columns splits the area into columns of the given widths, columns of width 0 share the space left
+func (r Rect) Columns(widths ...int) []Rect {
+	sizes := split(r.W, widths)
+	columns := make([]Rect, len(sizes))
+	x := r.X
+	for i, w := range sizes {
+		columns[i] = Rect{X: x, Y: r.Y, W: w, H: r.H}
+		x += w
+	}
+	return columns
+}
+
+// split gives fixed sizes as they are and the rest of total to sizes of 0
+func split(total int, sizes []int) []int {
+	result := append([]int(nil), sizes...)
+	left, shared := total, 0
+	for _, size := range sizes {
+		left -= size
+		if size == 0 {
+			shared++
+		}
+	}
+	for i, size := range result {
+		if size == 0 && left > 0 {
+			result[i] = left / shared
+			left -= result[i]
+			shared--
+		}
+	}
+	return result
+}
+
+// Anchor is the point of an area a box is placed at
+type Anchor int
+
+const (
+	TopLeft Anchor = iota
+	Top
+	TopRight
+	Left
+	Center
+	Right
+	BottomLeft
+	Bottom
+	BottomRight
+)
+
+// Place puts a box inside the area at the anchor, a box bigger than the area sticks out right and down
+func (r Rect) Place(box Box, anchor Anchor) Rect {
+	x, y := r.X, r.Y
+	switch anchor % 3 {
+	case 1:
+		x += max(r.W-box.W, 0) / 2
+	case 2:
+		x += max(r.W-box.W, 0)
+	}
+	switch anchor / 3 {
+	case 1:
+		y += max(r.H-box.H, 0) / 2
+	case 2:
+		y += max(r.H-box.H, 0)
+	}
+	return Rect{X: x, Y: y, W: box.W, H: box.H}
+}
+
+// Flow places boxes left to right with gap cells between them,
+// starting a new row when a box doesn't fit the width
+func (r Rect) Flow(gap int, boxes ...Box) []Rect {
+	rects := make([]Rect, len(boxes))
+	x, y, rowHeight := r.X, r.Y, 0
+	for i, box := range boxes {
+		if x > r.X && x+box.W > r.Right() {
+			x, y, rowHeight = r.X, y+rowHeight+gap, 0
+		}
+		rects[i] = Rect{X: x, Y: y, W: box.W, H: box.H}
+		x += box.W + gap
+		rowHeight = max(rowHeight, box.H)
+	}
+	return rects
+}
+
+// Stack places boxes top to bottom with gap cells between them,
+// starting a new column when a box doesn't fit the height
+func (r Rect) Stack(gap int, boxes ...Box) []Rect {
+	rects := make([]Rect, len(boxes))
+	x, y, columnWidth := r.X, r.Y, 0
+	for i, box := range boxes {
+		if y > r.Y && y+box.H > r.Bottom() {
+			x, y, columnWidth = x+columnWidth+gap, r.Y, 0
+		}
+		rects[i] = Rect{X: x, Y: y, W: box.W, H: box.H}
+		y += box.H + gap
+		columnWidth = max(columnWidth, box.W)
+	}
+	return rects
+}
+
+// Union returns the smallest area containing all the given ones
+func Union(rects ...Rect) Rect {
+	if len(rects) == 0 {
+		return Rect{}
+	}
+	u := rects[0]
+	for _, r := range rects[1:] {
+		right, bottom := max(u.Right(), r.Right()), max(u.Bottom(), r.Bottom())
+		u.X, u.Y = min(u.X, r.X), min(u.Y, r.Y)
+		u.W, u.H = right-u.X, bottom-u.Y
+	}
+	return u
+}
+
+// Message returns the top row of the screen, screens show their errors and notices there
+func Message() Rect {
+	area := Content().Pad(2)
+	return Rect{X: area.X, Y: 0, W: area.W, H: 1}
+}
+
+// MenuStatus returns the three rows of a menu screen between its title and its buttons
+func MenuStatus() Rect {
+	return Content().Pad(2).Rows(3, 0)[0]
+}
+
+// GameBoards returns where the player and opponent boards go, side by side
+// below two rows of status text, or one under the other if the terminal is narrow
+func GameBoards() (player Rect, opponent Rect) {
+	area := Content().Pad(1).Below(3)
+	board := Box{W: BoardWidth, H: BoardHeight}
+	if area.W >= 2*BoardWidth+4 {
+		columns := area.Columns(0, 0)
+		return columns[0].Place(board, TopLeft), columns[1].Place(board, TopLeft)
+	}
+	rows := area.Rows(BoardHeight+1, 0)
+	return rows[0].Place(board, TopLeft), rows[1].Place(board, TopLeft)
+}
+
+// Width of the hint and exit buttons at the end of the controls row
+const ControlWidth = 15
+
+// GameLayout is where things go on screens with both boards: the game, replays and the spectator
+type GameLayout struct {
+	Status           Rect // two rows of text above the boards
+	Player, Opponent Rect
+	Controls         Rect // the row under the boards left of the hint and exit buttons
+	Hint, Exit       Rect
+	PlayerInfo       Rect // text under the controls row, on the side of our board
+	OpponentInfo     Rect
+}
+
+// Game returns the layout of a screen with both boards
+func Game() GameLayout {
+	player, opponent := GameBoards()
+	area := Content().Pad(1)
+	boards := Union(player, opponent)
+	row := Rect{X: boards.X, Y: boards.Bottom() + 1, W: max(boards.W, 3*ControlWidth+2), H: 3}
+	columns := row.Columns(0, 1, ControlWidth, 1, ControlWidth)
+	g := GameLayout{
+		Status:   Rect{X: area.X, Y: area.Y, W: area.W, H: max(player.Y-area.Y, 0)},
+		Player:   player,
+		Opponent: opponent,
+		Controls: columns[0],
+		Hint:     columns[2],
+		Exit:     columns[4],
+	}
+	info := area.Below(row.Bottom() + 1)
+	if player.X != opponent.X {
+		g.PlayerInfo = Rect{X: player.X, Y: info.Y, W: opponent.X - player.X, H: info.H}
+		g.OpponentInfo = Rect{X: opponent.X, Y: info.Y, W: max(info.Right()-opponent.X, 0), H: info.H}
+	} else {
+		sides := info.Columns(0, 0)
+		g.PlayerInfo, g.OpponentInfo = sides[0], sides[1]
+	}
+	return g
+}
+
+// Repeat returns n boxes of the same size
+func Repeat(box Box, n int) []Box {
+	boxes := make([]Box, n)
+	for i := range boxes {
+		boxes[i] = box
+	}
+	return boxes
+}
@@ -0,0 +1,68 @@
+package layout
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFlow(t *testing.T) {
+	area := Rect{X: 2, Y: 5, W: 20, H: 10}
+	tests := []struct {
+		name  string
+		boxes []Box
+		want  []Rect
+	}{
+		{"one row", []Box{{5, 3}, {5, 3}}, []Rect{{2, 5, 5, 3}, {9, 5, 5, 3}}},
+		{"wraps", []Box{{8, 3}, {8, 3}, {8, 2}}, []Rect{{2, 5, 8, 3}, {12, 5, 8, 3}, {2, 10, 8, 2}}},
+		{"wider than the area", []Box{{30, 1}, {4, 1}}, []Rect{{2, 5, 30, 1}, {2, 8, 4, 1}}},
+		{"tallest box sets the row", []Box{{5, 1}, {5, 4}, {15, 1}}, []Rect{{2, 5, 5, 1}, {9, 5, 5, 4}, {2, 11, 15, 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := area.Flow(2, tt.boxes...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Flow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStack(t *testing.T) {
+	area := Rect{X: 2, Y: 5, W: 40, H: 8}
+	tests := []struct {
+		name  string
+		boxes []Box
+		want  []Rect
+	}{
+		{"one column", []Box{{10, 3}, {10, 3}}, []Rect{{2, 5, 10, 3}, {2, 9, 10, 3}}},
+		{"wraps", []Box{{10, 3}, {10, 3}, {6, 3}}, []Rect{{2, 5, 10, 3}, {2, 9, 10, 3}, {13, 5, 6, 3}}},
+		{"taller than the area", []Box{{4, 12}, {4, 1}}, []Rect{{2, 5, 4, 12}, {7, 5, 4, 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := area.Stack(1, tt.boxes...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Stack() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColumnsShareTheSpaceLeft(t *testing.T) {
+	got := Rect{X: 1, Y: 0, W: 31, H: 3}.Columns(0, 1, 10, 1, 0)
+	want := []Rect{{1, 0, 9, 3}, {10, 0, 1, 3}, {11, 0, 10, 3}, {21, 0, 1, 3}, {22, 0, 10, 3}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Columns() = %v, want %v", got, want)
+	}
+}
+
+func TestMenuStatusEndsAboveTheButtons(t *testing.T) {
+	status := MenuStatus()
+	if want := (Rect{X: 2, Y: 2, W: Content().W - 4, H: 3}); status != want {
+		t.Errorf("MenuStatus() = %v, want %v", status, want)
+	}
+	if buttons := Content().Pad(2).Below(5); status.Bottom() != buttons.Y {
+		t.Errorf("MenuStatus() ends at %d, buttons start at %d", status.Bottom(), buttons.Y)
+	}
+	if message := Message(); message.Bottom() > status.Y {
+		t.Errorf("Message() = %v overlaps the menu status %v", message, status)
+	}
+}
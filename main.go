@@ -3,13 +3,117 @@ package main
 import (
 	"BomboweStatki/client"
 	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
 
 	gui "github.com/s25867/warships-gui/v2"
 )
 
+const usage = `Usage: statki [global flags] <command> [arguments]
+
+Commands:
+  play                          start the terminal UI (default)
+  text [--target N] [--wpbot]   play in plain text, reading shots from stdin
+  lobby                         print players waiting in the lobby
+  stats [nick]                  print the leaderboard or stats of one player
+  layout random|validate|show   generate, check or draw a ship layout
+  bot --strategy X --target N   play a game using a bot strategy
+  simulate                      play local bot games and compare strategies
+
+Global flags:
+`
+
+// options are the global flags shared by all commands
+type options struct {
+	server      string
+	nick        string
+	profileName string
+	output      string
+	verbose     bool
+
+	// set up from the flags before running a command
+	api     *client.APIClient
+	profile client.Profile
+	logs    *client.LogHistory
+}
+
 func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	var opts options
+	flags := flag.NewFlagSet("statki", flag.ContinueOnError)
+	flags.StringVar(&opts.server, "server", client.DefaultServerURL, "game server URL")
+	flags.StringVar(&opts.nick, "nick", "", "nick to play with, overrides the profile")
+	flags.StringVar(&opts.profileName, "profile", "default", "name of the profile to use")
+	flags.StringVar(&opts.output, "output", "table", "output format: table or json")
+	flags.BoolVar(&opts.verbose, "verbose", false, "log debug messages to logs/statki.log and show info messages in the log panel")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), usage)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if opts.output != "table" && opts.output != "json" {
+		fmt.Fprintln(os.Stderr, "output must be table or json")
+		return 2
+	}
+
+	logs, closeLog, err := client.SetupLogging(opts.verbose)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Logging to a file is off:", err)
+	}
+	defer closeLog()
+	opts.logs = logs
+
+	opts.api = client.NewAPIClient(opts.server)
+	profile, err := client.LoadProfile(opts.profileName)
+	if errors.Is(err, client.ErrInvalidLayout) {
+		fmt.Fprintln(os.Stderr, err)
+	} else if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if opts.nick != "" {
+		profile.Nick = opts.nick
+	}
+	opts.profile = profile
+
+	command, commandArgs := "play", []string{}
+	if flags.NArg() > 0 {
+		command, commandArgs = flags.Arg(0), flags.Args()[1:]
+	}
+
+	commands := map[string]func(opts options, args []string) error{
+		"play":     playCommand,
+		"text":     textCommand,
+		"lobby":    lobbyCommand,
+		"stats":    statsCommand,
+		"layout":   layoutCommand,
+		"bot":      botCommand,
+		"simulate": simulateCommand,
+	}
+	cmd, ok := commands[command]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", command)
+		flags.Usage()
+		return 2
+	}
+	if err := cmd(opts, commandArgs); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return 1
+	}
+	return 0
+}
+
+func playCommand(opts options, args []string) error {
 	ui := gui.NewGUI(false)
 	ctx := context.Background()
-	go client.MainMenu(ui)
+	go client.NewApp(client.NewUI(ui), opts.api, opts.profile, opts.profileName, opts.logs).Run()
 	ui.Start(ctx, nil)
+	return nil
 }